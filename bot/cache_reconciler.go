@@ -0,0 +1,400 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ CacheReconciler = (*cacheReconcilerImpl)(nil)
+
+// ErrCacheReconcilerRunning is returned by CacheReconciler.Start when the CacheReconciler is already running.
+var ErrCacheReconcilerRunning = errors.New("cache reconciler already running")
+
+// NewCacheReconciler returns a new CacheReconciler with the CacheReconcilerConfigOpt(s) applied.
+func NewCacheReconciler(client *Client, opts ...CacheReconcilerConfigOpt) CacheReconciler {
+	cfg := defaultCacheReconcilerConfig()
+	cfg.apply(opts)
+
+	return &cacheReconcilerImpl{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CacheReconciler fetches guild entities via the rest.Rest and repairs differences to the cache.Caches
+// which can happen when events are missed during long outages or when intents change at runtime.
+type CacheReconciler interface {
+	// Reconcile reconciles the cached entities of the given guild with the REST API.
+	// Guilds which are not handled by the shards of this Client are skipped with ErrShardNotOwned.
+	Reconcile(ctx context.Context, guildID snowflake.ID) (CacheReconcileResult, error)
+
+	// ReconcileAll reconciles all cached guilds one after another and returns the first error encountered.
+	// Guilds which are not handled by the shards of this Client are skipped.
+	ReconcileAll(ctx context.Context) error
+
+	// Start reconciles all cached guilds every interval until the ctx is done or Close is called.
+	Start(ctx context.Context, interval time.Duration) error
+
+	// Close stops reconciling started by Start and waits for the current run to finish.
+	Close(ctx context.Context)
+
+	// Metrics returns the cumulative CacheReconcilerMetrics since the CacheReconciler was created.
+	Metrics() CacheReconcilerMetrics
+}
+
+// ErrShardNotOwned is returned when a guild is not handled by the shards of this Client.
+var ErrShardNotOwned = errors.New("guild is not handled by a shard of this client")
+
+// CacheReconcileDiff describes the differences found for one entity type.
+type CacheReconcileDiff struct {
+	// Added is the number of entities which were missing from the cache.
+	Added int
+	// Updated is the number of cached entities which were outdated.
+	Updated int
+	// Removed is the number of cached entities which do no longer exist.
+	Removed int
+}
+
+// Changed returns true if any differences were found.
+func (d CacheReconcileDiff) Changed() bool {
+	return d.Added > 0 || d.Updated > 0 || d.Removed > 0
+}
+
+// CacheReconcileResult is the result of reconciling a single guild.
+type CacheReconcileResult struct {
+	GuildID  snowflake.ID
+	Roles    CacheReconcileDiff
+	Channels CacheReconcileDiff
+	Emojis   CacheReconcileDiff
+	Stickers CacheReconcileDiff
+	// Members is only filled if WithCacheReconcilerMembers is set.
+	Members  CacheReconcileDiff
+	Duration time.Duration
+}
+
+// Changed returns true if any differences were found.
+func (r CacheReconcileResult) Changed() bool {
+	return r.Roles.Changed() || r.Channels.Changed() || r.Emojis.Changed() || r.Stickers.Changed() || r.Members.Changed()
+}
+
+// CacheReconcilerMetrics are cumulative counters of a CacheReconciler which can be exported as metrics.
+type CacheReconcilerMetrics struct {
+	// Reconciled is the number of successfully reconciled guilds.
+	Reconciled uint64
+	// Changed is the number of reconciled guilds with differences.
+	Changed uint64
+	// Failed is the number of guilds which failed to reconcile.
+	Failed uint64
+	// Roles, Channels, Emojis, Stickers and Members are the total differences found per entity type.
+	Roles    CacheReconcileDiff
+	Channels CacheReconcileDiff
+	Emojis   CacheReconcileDiff
+	Stickers CacheReconcileDiff
+	Members  CacheReconcileDiff
+	// LastDuration is the duration of the last successful reconciliation.
+	LastDuration time.Duration
+}
+
+func (m *CacheReconcilerMetrics) add(result CacheReconcileResult) {
+	m.Reconciled++
+	if result.Changed() {
+		m.Changed++
+	}
+	m.Roles.add(result.Roles)
+	m.Channels.add(result.Channels)
+	m.Emojis.add(result.Emojis)
+	m.Stickers.add(result.Stickers)
+	m.Members.add(result.Members)
+	m.LastDuration = result.Duration
+}
+
+func (d *CacheReconcileDiff) add(diff CacheReconcileDiff) {
+	d.Added += diff.Added
+	d.Updated += diff.Updated
+	d.Removed += diff.Removed
+}
+
+type cacheReconcilerImpl struct {
+	client *Client
+	config cacheReconcilerConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	metricsMu sync.Mutex
+	metrics   CacheReconcilerMetrics
+}
+
+func (r *cacheReconcilerImpl) Reconcile(ctx context.Context, guildID snowflake.ID) (CacheReconcileResult, error) {
+	if !r.client.ownsGuild(guildID) {
+		return CacheReconcileResult{GuildID: guildID}, ErrShardNotOwned
+	}
+
+	result, err := r.reconcile(ctx, guildID)
+	r.metricsMu.Lock()
+	if err != nil {
+		r.metrics.Failed++
+	} else {
+		r.metrics.add(result)
+	}
+	r.metricsMu.Unlock()
+	if err != nil {
+		return result, err
+	}
+
+	if result.Changed() {
+		r.config.Logger.Debug("reconciled guild cache", slog.Any("guild_id", guildID), slog.Any("result", result))
+	}
+	if r.config.ResultHandler != nil {
+		r.config.ResultHandler(result)
+	}
+	return result, nil
+}
+
+// reconcile fetches the entities of the guild and reconciles them with the cache.
+// The cached entities are captured before each fetch, so entities changed by the gateway in the meantime are left untouched.
+func (r *cacheReconcilerImpl) reconcile(ctx context.Context, guildID snowflake.ID) (CacheReconcileResult, error) {
+	result := CacheReconcileResult{GuildID: guildID}
+	start := time.Now()
+	opts := []rest.RequestOpt{rest.WithCtx(ctx)}
+	caches := r.client.Caches
+
+	roleID := func(role discord.Role) snowflake.ID { return role.ID }
+	cachedRoles := snapshotEntities(caches.Roles(guildID), roleID)
+	roles, err := r.client.Rest.GetRoles(guildID, opts...)
+	if err != nil {
+		return result, err
+	}
+	result.Roles = reconcileEntities(cachedRoles, caches.Roles(guildID), roles, roleID,
+		caches.AddRole,
+		func(roleID snowflake.ID) { caches.RemoveRole(guildID, roleID) },
+	)
+
+	cachedChannels := snapshotEntities(nonThreadChannels(caches.ChannelsForGuild(guildID)), discord.GuildChannel.ID)
+	channels, err := r.client.Rest.GetGuildChannels(guildID, opts...)
+	if err != nil {
+		return result, err
+	}
+	result.Channels = reconcileEntities(cachedChannels, nonThreadChannels(caches.ChannelsForGuild(guildID)), channels, discord.GuildChannel.ID,
+		caches.AddChannel,
+		func(channelID snowflake.ID) { caches.RemoveChannel(channelID) },
+	)
+
+	emojiID := func(emoji discord.Emoji) snowflake.ID { return emoji.ID }
+	cachedEmojis := snapshotEntities(caches.Emojis(guildID), emojiID)
+	emojis, err := r.client.Rest.GetEmojis(guildID, opts...)
+	if err != nil {
+		return result, err
+	}
+	result.Emojis = reconcileEntities(cachedEmojis, caches.Emojis(guildID), emojis, emojiID,
+		caches.AddEmoji,
+		func(emojiID snowflake.ID) { caches.RemoveEmoji(guildID, emojiID) },
+	)
+
+	stickerID := func(sticker discord.Sticker) snowflake.ID { return sticker.ID }
+	cachedStickers := snapshotEntities(caches.Stickers(guildID), stickerID)
+	stickers, err := r.client.Rest.GetStickers(guildID, opts...)
+	if err != nil {
+		return result, err
+	}
+	for i := range stickers {
+		stickers[i].GuildID = &guildID
+	}
+	result.Stickers = reconcileEntities(cachedStickers, caches.Stickers(guildID), stickers, stickerID,
+		caches.AddSticker,
+		func(stickerID snowflake.ID) { caches.RemoveSticker(guildID, stickerID) },
+	)
+
+	if r.config.Members {
+		memberID := func(member discord.Member) snowflake.ID { return member.User.ID }
+		cachedMembers := snapshotEntities(caches.Members(guildID), memberID)
+		var members []discord.Member
+		if members, err = r.fetchMembers(guildID, opts); err != nil {
+			return result, err
+		}
+		result.Members = reconcileEntities(cachedMembers, caches.Members(guildID), members, memberID,
+			caches.AddMember,
+			func(userID snowflake.ID) { caches.RemoveMember(guildID, userID) },
+		)
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (r *cacheReconcilerImpl) fetchMembers(guildID snowflake.ID, opts []rest.RequestOpt) ([]discord.Member, error) {
+	const limit = 1000
+
+	var (
+		members []discord.Member
+		after   snowflake.ID
+	)
+	for {
+		page, err := r.client.Rest.GetMembers(guildID, limit, after, opts...)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) < limit {
+			return members, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+}
+
+func (r *cacheReconcilerImpl) ReconcileAll(ctx context.Context) error {
+	var guildIDs []snowflake.ID
+	for guild := range r.client.Caches.Guilds() {
+		guildIDs = append(guildIDs, guild.ID)
+	}
+
+	var firstErr error
+	for i, guildID := range guildIDs {
+		if i > 0 && r.config.GuildDelay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(r.config.GuildDelay):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := r.Reconcile(ctx, guildID); err != nil {
+			if errors.Is(err, ErrShardNotOwned) {
+				continue
+			}
+			r.config.Logger.Error("failed to reconcile guild cache", slog.Any("guild_id", guildID), slog.Any("err", err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (r *cacheReconcilerImpl) Start(ctx context.Context, interval time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return ErrCacheReconcilerRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	r.cancel = cancel
+	r.done = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = r.ReconcileAll(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *cacheReconcilerImpl) Metrics() CacheReconcilerMetrics {
+	r.metricsMu.Lock()
+	defer r.metricsMu.Unlock()
+	return r.metrics
+}
+
+func (r *cacheReconcilerImpl) Close(ctx context.Context) {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-done:
+	}
+}
+
+// nonThreadChannels filters out threads as they are not returned by rest.Guilds.GetGuildChannels.
+func nonThreadChannels(channels iter.Seq[discord.GuildChannel]) iter.Seq[discord.GuildChannel] {
+	return func(yield func(discord.GuildChannel) bool) {
+		for channel := range channels {
+			if _, ok := channel.(discord.GuildThread); ok {
+				continue
+			}
+			if !yield(channel) {
+				return
+			}
+		}
+	}
+}
+
+func snapshotEntities[T any](cached iter.Seq[T], idFunc func(T) snowflake.ID) map[snowflake.ID]T {
+	entities := make(map[snowflake.ID]T)
+	for entity := range cached {
+		entities[idFunc(entity)] = entity
+	}
+	return entities
+}
+
+// reconcileEntities applies the differences between the cached and fetched entities to the cache.
+// before are the cached entities captured before the fetch. Entities which were added, updated or removed
+// by the gateway since then are newer than the fetched ones and are skipped.
+func reconcileEntities[T any](before map[snowflake.ID]T, cached iter.Seq[T], fetched []T, idFunc func(T) snowflake.ID, add func(T), remove func(snowflake.ID)) CacheReconcileDiff {
+	var diff CacheReconcileDiff
+
+	cachedEntities := snapshotEntities(cached, idFunc)
+	for _, entity := range fetched {
+		id := idFunc(entity)
+		beforeEntity, existedBefore := before[id]
+		cachedEntity, ok := cachedEntities[id]
+		if !ok {
+			// removed by the gateway during the fetch
+			if existedBefore {
+				continue
+			}
+			diff.Added++
+			add(entity)
+			continue
+		}
+		delete(cachedEntities, id)
+		// added or updated by the gateway during the fetch
+		if !existedBefore || !reflect.DeepEqual(beforeEntity, cachedEntity) {
+			continue
+		}
+		if !reflect.DeepEqual(cachedEntity, entity) {
+			diff.Updated++
+			add(entity)
+		}
+	}
+
+	for id, cachedEntity := range cachedEntities {
+		// added or updated by the gateway during the fetch
+		if beforeEntity, ok := before[id]; !ok || !reflect.DeepEqual(beforeEntity, cachedEntity) {
+			continue
+		}
+		diff.Removed++
+		remove(id)
+	}
+	return diff
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"log/slog"
+	"time"
+)
+
+func defaultCacheReconcilerConfig() cacheReconcilerConfig {
+	return cacheReconcilerConfig{
+		Logger: slog.Default(),
+	}
+}
+
+type cacheReconcilerConfig struct {
+	Logger        *slog.Logger
+	Members       bool
+	GuildDelay    time.Duration
+	ResultHandler func(result CacheReconcileResult)
+}
+
+// CacheReconcilerConfigOpt is a functional option for configuring a CacheReconciler.
+type CacheReconcilerConfigOpt func(config *cacheReconcilerConfig)
+
+func (c *cacheReconcilerConfig) apply(opts []CacheReconcilerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "bot_cache_reconciler"))
+}
+
+// WithCacheReconcilerLogger overrides the default Logger in the cacheReconcilerConfig.
+func WithCacheReconcilerLogger(logger *slog.Logger) CacheReconcilerConfigOpt {
+	return func(config *cacheReconcilerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithCacheReconcilerMembers enables reconciling members. This fetches all members of a guild page by page
+// and should only be used for small guilds or long intervals.
+// Notice: This requires the gateway.IntentGuildMembers.
+func WithCacheReconcilerMembers() CacheReconcilerConfigOpt {
+	return func(config *cacheReconcilerConfig) {
+		config.Members = true
+	}
+}
+
+// WithCacheReconcilerGuildDelay sets the delay between reconciling two guilds in CacheReconciler.ReconcileAll
+// to spread the REST requests over time. Rate limits are handled by the rest.RateLimiter regardless.
+func WithCacheReconcilerGuildDelay(delay time.Duration) CacheReconcilerConfigOpt {
+	return func(config *cacheReconcilerConfig) {
+		config.GuildDelay = delay
+	}
+}
+
+// WithCacheReconcilerResultHandler sets a func which is called with the CacheReconcileResult of each reconciled guild.
+// This can be used to export metrics.
+func WithCacheReconcilerResultHandler(handler func(result CacheReconcileResult)) CacheReconcilerConfigOpt {
+	return func(config *cacheReconcilerConfig) {
+		config.ResultHandler = handler
+	}
+}
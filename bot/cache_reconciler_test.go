@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ rest.Client = (*testRestClient)(nil)
+
+// testRestClient responds to REST requests with the JSON encoded responses of the endpoints.
+type testRestClient struct {
+	responses map[*rest.Endpoint]func() any
+}
+
+func (c *testRestClient) HTTPClient() *http.Client      { return http.DefaultClient }
+func (c *testRestClient) RateLimiter() rest.RateLimiter { return nil }
+func (c *testRestClient) Close(_ context.Context)       {}
+func (c *testRestClient) Do(endpoint *rest.CompiledEndpoint, _ any, rsBody any, _ ...rest.RequestOpt) error {
+	response, ok := c.responses[endpoint.Endpoint]
+	if !ok {
+		return fmt.Errorf("unexpected request: %s", endpoint.URL)
+	}
+	data, err := json.Marshal(response())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, rsBody)
+}
+
+func TestCacheReconciler(t *testing.T) {
+	const guildID snowflake.ID = 1
+
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	caches.AddGuild(discord.Guild{ID: guildID})
+	caches.AddRole(discord.Role{ID: 10, GuildID: guildID, Name: "stale"})
+	caches.AddRole(discord.Role{ID: 11, GuildID: guildID, Name: "deleted"})
+	caches.AddRole(discord.Role{ID: 12, GuildID: guildID, Name: "unchanged"})
+
+	client := &Client{Caches: caches}
+	client.Rest = rest.New(&testRestClient{responses: map[*rest.Endpoint]func() any{
+		rest.GetRoles: func() any {
+			// the gateway adds a role while the roles are fetched
+			caches.AddRole(discord.Role{ID: 14, GuildID: guildID, Name: "created during fetch"})
+			return []discord.Role{
+				{ID: 10, GuildID: guildID, Name: "fresh"},
+				{ID: 12, GuildID: guildID, Name: "unchanged"},
+				{ID: 13, GuildID: guildID, Name: "missing"},
+			}
+		},
+		rest.GetGuildChannels: func() any { return []discord.GuildChannel{} },
+		rest.GetEmojis:        func() any { return []discord.Emoji{} },
+		rest.GetGuildStickers: func() any { return []discord.Sticker{} },
+	}})
+
+	var handled []CacheReconcileResult
+	reconciler := NewCacheReconciler(client, WithCacheReconcilerResultHandler(func(result CacheReconcileResult) {
+		handled = append(handled, result)
+	}))
+
+	result, err := reconciler.Reconcile(context.Background(), guildID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (CacheReconcileDiff{Added: 1, Updated: 1, Removed: 1}); result.Roles != expected {
+		t.Errorf("expected role diff %+v, got %+v", expected, result.Roles)
+	}
+
+	var roleNames []string
+	for role := range caches.Roles(guildID) {
+		roleNames = append(roleNames, role.Name)
+	}
+	slices.Sort(roleNames)
+	if expected := []string{"created during fetch", "fresh", "missing", "unchanged"}; !slices.Equal(roleNames, expected) {
+		t.Errorf("expected roles %v, got %v", expected, roleNames)
+	}
+
+	metrics := reconciler.Metrics()
+	if metrics.Reconciled != 1 || metrics.Changed != 1 || metrics.Roles != result.Roles || len(handled) != 1 {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestReconcileEntities(t *testing.T) {
+	type entity struct {
+		ID    snowflake.ID
+		Value string
+	}
+	idFunc := func(e entity) snowflake.ID { return e.ID }
+
+	tests := []struct {
+		name     string
+		before   []entity
+		cached   []entity
+		fetched  []entity
+		expected []entity
+		diff     CacheReconcileDiff
+	}{
+		{
+			name:     "add missing",
+			fetched:  []entity{{ID: 1}},
+			expected: []entity{{ID: 1}},
+			diff:     CacheReconcileDiff{Added: 1},
+		},
+		{
+			name:     "update outdated",
+			before:   []entity{{ID: 1, Value: "old"}},
+			cached:   []entity{{ID: 1, Value: "old"}},
+			fetched:  []entity{{ID: 1, Value: "new"}},
+			expected: []entity{{ID: 1, Value: "new"}},
+			diff:     CacheReconcileDiff{Updated: 1},
+		},
+		{
+			name:   "remove deleted",
+			before: []entity{{ID: 1}},
+			cached: []entity{{ID: 1}},
+			diff:   CacheReconcileDiff{Removed: 1},
+		},
+		{
+			name:     "keep added during fetch",
+			cached:   []entity{{ID: 1}},
+			expected: []entity{{ID: 1}},
+		},
+		{
+			name:     "keep updated during fetch",
+			before:   []entity{{ID: 1, Value: "old"}},
+			cached:   []entity{{ID: 1, Value: "gateway"}},
+			fetched:  []entity{{ID: 1, Value: "rest"}},
+			expected: []entity{{ID: 1, Value: "gateway"}},
+		},
+		{
+			name:    "skip removed during fetch",
+			before:  []entity{{ID: 1}},
+			fetched: []entity{{ID: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cached := make(map[snowflake.ID]entity)
+			for _, e := range tt.cached {
+				cached[e.ID] = e
+			}
+
+			diff := reconcileEntities(snapshotEntities(slices.Values(tt.before), idFunc), maps.Values(cached), tt.fetched, idFunc,
+				func(e entity) { cached[e.ID] = e },
+				func(id snowflake.ID) { delete(cached, id) },
+			)
+			if diff != tt.diff {
+				t.Errorf("expected diff %+v, got %+v", tt.diff, diff)
+			}
+
+			expected := make(map[snowflake.ID]entity)
+			for _, e := range tt.expected {
+				expected[e.ID] = e
+			}
+			if !maps.Equal(cached, expected) {
+				t.Errorf("expected cache %+v, got %+v", expected, cached)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package bot
 import (
 	"context"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
 
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
@@ -30,24 +33,138 @@ type Client struct {
 	VoiceManager          voice.Manager
 	Caches                cache.Caches
 	MemberChunkingManager MemberChunkingManager
+	CacheReconciler       CacheReconciler
+	Scheduler             Scheduler
+	Modules               []Module
+
+	modulesMu      sync.Mutex
+	startedModules int
+
+	gatewaySessionSaver GatewaySessionSaver
+}
+
+// ShutdownReport describes what was abandoned when the Client was shut down.
+type ShutdownReport struct {
+	// AbandonedListeners is the number of EventListener calls which did not finish before the ctx was done.
+	AbandonedListeners int
+	// Sessions are the gateway sessions by shard ID which were passed to the GatewaySessionSaver.
+	Sessions map[int]sharding.ShardState
+	// SessionSaveErr is the error returned by the GatewaySessionSaver.
+	SessionSaveErr error
+	// Duration is the time the shutdown took.
+	Duration time.Duration
 }
 
+// GatewaySessionSaver is called during Client.Shutdown with the resumable gateway sessions by shard ID.
+// They can be resumed after a restart with sharding.ShardManager.ResumeShard or gateway.WithSessionID, gateway.WithSequence and gateway.WithResumeURL.
+type GatewaySessionSaver func(ctx context.Context, sessions map[int]sharding.ShardState) error
+
+// Close gracefully shuts down the Client like Shutdown and logs anything which was abandoned.
 func (c *Client) Close(ctx context.Context) {
+	report := c.Shutdown(ctx)
+	if report.AbandonedListeners > 0 {
+		c.Logger.Warn("abandoned running event listeners during shutdown", slog.Int("listeners", report.AbandonedListeners))
+	}
+	if report.SessionSaveErr != nil {
+		c.Logger.Error("failed to save gateway sessions during shutdown", slog.Any("err", report.SessionSaveErr))
+	}
+}
+
+// Shutdown gracefully shuts down the Client. It stops handling new gateway events and HTTP interactions,
+// waits for running EventListener(s) until the ctx is done, stops the Module(s), saves the gateway sessions if a GatewaySessionSaver is configured
+// and only then closes all connections. Closing the rest.Rest waits until the ctx is done for requests holding a rate limit bucket
+// and closes idle connections, it does not cancel requests which are in flight.
+func (c *Client) Shutdown(ctx context.Context) ShutdownReport {
+	start := time.Now()
+	var report ShutdownReport
+
+	if c.EventManager != nil {
+		report.AbandonedListeners = c.EventManager.Drain(ctx)
+	}
+
+	if c.Scheduler != nil {
+		c.Scheduler.Close(ctx)
+	}
+
+	c.modulesMu.Lock()
+	c.stopModules(ctx)
+	c.modulesMu.Unlock()
+
+	if c.CacheReconciler != nil {
+		c.CacheReconciler.Close(ctx)
+	}
+
+	resumable := false
+	if c.gatewaySessionSaver != nil {
+		report.Sessions = c.gatewaySessions()
+		report.SessionSaveErr = c.gatewaySessionSaver(ctx, report.Sessions)
+		resumable = report.SessionSaveErr == nil
+	}
+
 	if c.VoiceManager != nil {
 		c.VoiceManager.Close(ctx)
 	}
 	if c.Gateway != nil {
-		c.Gateway.Close(ctx)
+		closeGateway(ctx, c.Gateway, resumable)
+	}
+	if c.ShardManager != nil {
+		if resumable {
+			// the shards are closed with a code which keeps the sessions valid instead of closing them again with ShardManager.Close
+			for shard := range c.ShardManager.Shards() {
+				closeGateway(ctx, shard, resumable)
+			}
+		} else {
+			c.ShardManager.Close(ctx)
+		}
+	}
+	if c.HTTPServer != nil {
+		c.HTTPServer.Close(ctx)
 	}
 	if c.Rest != nil {
 		c.Rest.Close(ctx)
 	}
+
+	report.Duration = time.Since(start)
+	return report
+}
+
+// gatewaySessions returns the resumable sessions of all shards with the sequence of the last handled event.
+func (c *Client) gatewaySessions() map[int]sharding.ShardState {
+	sessions := make(map[int]sharding.ShardState)
+	addSession := func(shard gateway.Gateway) {
+		sessionID := shard.SessionID()
+		sequence, ok := c.EventManager.HandledSequence(shard.ShardID())
+		if sessionID == nil || !ok {
+			return
+		}
+		state := sharding.ShardState{
+			SessionID: *sessionID,
+			Sequence:  sequence,
+		}
+		if resumeURL := shard.ResumeURL(); resumeURL != nil {
+			state.ResumeURL = *resumeURL
+		}
+		sessions[shard.ShardID()] = state
+	}
+
+	if c.Gateway != nil {
+		addSession(c.Gateway)
+	}
 	if c.ShardManager != nil {
-		c.ShardManager.Close(ctx)
+		for shard := range c.ShardManager.Shards() {
+			addSession(shard)
+		}
 	}
-	if c.HTTPServer != nil {
-		c.HTTPServer.Close(ctx)
+	return sessions
+}
+
+// closeGateway closes the gateway.Gateway. Resumable gateways are closed with a code which keeps the session valid.
+func closeGateway(ctx context.Context, g gateway.Gateway, resumable bool) {
+	if resumable {
+		g.CloseWithCode(ctx, websocket.CloseServiceRestart, "restarting")
+		return
 	}
+	g.Close(ctx)
 }
 
 func (c *Client) ID() snowflake.ID {
@@ -69,6 +186,9 @@ func (c *Client) OpenGateway(ctx context.Context) error {
 	if c.Gateway == nil {
 		return discord.ErrNoGateway
 	}
+	if err := c.StartModules(ctx); err != nil {
+		return err
+	}
 	return c.Gateway.Open(ctx)
 }
 
@@ -80,6 +200,9 @@ func (c *Client) OpenShardManager(ctx context.Context) error {
 	if c.ShardManager == nil {
 		return discord.ErrNoShardManager
 	}
+	if err := c.StartModules(ctx); err != nil {
+		return err
+	}
 	c.ShardManager.Open(ctx)
 	return nil
 }
@@ -100,6 +223,30 @@ func (c *Client) Shard(guildID snowflake.ID) (gateway.Gateway, error) {
 	return nil, discord.ErrNoGatewayOrShardManager
 }
 
+// ownsGuild returns whether the guild is handled by a shard of this Client. Clients without a gateway own all guilds.
+func (c *Client) ownsGuild(guildID snowflake.ID) bool {
+	switch {
+	case c.HasGateway():
+		return sharding.ShardIDByGuild(guildID, max(c.Gateway.ShardCount(), 1)) == c.Gateway.ShardID()
+	case c.HasShardManager():
+		return c.ShardManager.ShardByGuildID(guildID) != nil
+	default:
+		return true
+	}
+}
+
+// ownsShard returns whether the shard is handled by this Client. Clients without a gateway own all shards.
+func (c *Client) ownsShard(shardID int) bool {
+	switch {
+	case c.HasGateway():
+		return c.Gateway.ShardID() == shardID
+	case c.HasShardManager():
+		return c.ShardManager.Shard(shardID) != nil
+	default:
+		return true
+	}
+}
+
 func (c *Client) UpdateVoiceState(ctx context.Context, guildID snowflake.ID, channelID *snowflake.ID, selfMute bool, selfDeaf bool) error {
 	shard, err := c.Shard(guildID)
 	if err != nil {
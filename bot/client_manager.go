@@ -0,0 +1,274 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/internal/tokenhelper"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ ClientManager = (*clientManagerImpl)(nil)
+
+var (
+	// ErrClientExists is returned by ClientManager.Add when a Client for the application of the token is already managed.
+	ErrClientExists = errors.New("client for this application already exists")
+	// ErrClientManagerClosed is returned by ClientManager.Add when the ClientManager was closed.
+	ErrClientManagerClosed = errors.New("client manager closed")
+)
+
+// ClientFactory creates a new Client for the token, e.g. disgo.New.
+type ClientFactory func(token string, opts ...ConfigOpt) (*Client, error)
+
+// NewClientManager returns a new ClientManager which creates its Client(s) with the given ClientFactory and the ClientManagerConfigOpt(s) applied.
+func NewClientManager(factory ClientFactory, opts ...ClientManagerConfigOpt) ClientManager {
+	cfg := defaultClientManagerConfig()
+	cfg.apply(opts)
+
+	return &clientManagerImpl{
+		factory: factory,
+		config:  cfg,
+		clients: make(map[snowflake.ID]*managedClient),
+	}
+}
+
+// ClientManager manages many Client(s) with different tokens in one process, e.g. to host white-label bots.
+// All Client(s) share the http.Client of the rest.Client while rate limits stay isolated per token.
+type ClientManager interface {
+	// Add creates a Client for the token with the shared and the given tenant ConfigOpt(s) and opens its gateway or shard manager.
+	Add(ctx context.Context, token string, opts ...ConfigOpt) (*Client, error)
+
+	// Remove closes and removes the Client of the given application and returns whether it was managed.
+	Remove(ctx context.Context, applicationID snowflake.ID) bool
+
+	// Client returns the Client of the given application and a bool whether it was found or not.
+	Client(applicationID snowflake.ID) (*Client, bool)
+
+	// Clients returns all managed Client(s).
+	Clients() iter.Seq[*Client]
+
+	// Health returns the aggregated health of all managed Client(s).
+	Health() ClientManagerHealth
+
+	// Close closes all Client(s) concurrently, waits until they are closed and then closes the idle connections of the shared http.Client.
+	Close(ctx context.Context)
+}
+
+// ShardHealth is the health of a single shard of a Client.
+type ShardHealth struct {
+	ShardID int
+	Status  gateway.Status
+	Latency time.Duration
+}
+
+// ClientHealth is the health of a single Client managed by a ClientManager.
+type ClientHealth struct {
+	ApplicationID snowflake.ID
+	StartedAt     time.Time
+	Shards        []ShardHealth
+	Guilds        int
+	CacheStats    []cache.Stats
+}
+
+// ClientManagerHealth is the aggregated health of all Client(s) managed by a ClientManager.
+type ClientManagerHealth struct {
+	Clients []ClientHealth
+	// ShardsReady is the number of shards of all Client(s) which are ready.
+	ShardsReady int
+	// Shards is the number of shards of all Client(s).
+	Shards int
+	// Guilds is the number of cached guilds of all Client(s).
+	Guilds int
+}
+
+type clientManagerImpl struct {
+	factory ClientFactory
+	config  clientManagerConfig
+
+	mu      sync.Mutex
+	closed  bool
+	clients map[snowflake.ID]*managedClient
+}
+
+type managedClient struct {
+	client    *Client
+	startedAt time.Time
+}
+
+func (m *clientManagerImpl) Add(ctx context.Context, token string, opts ...ConfigOpt) (*Client, error) {
+	id, err := tokenhelper.IDFromToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting application id from Token: %w", err)
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, ErrClientManagerClosed
+	}
+	if _, ok := m.clients[*id]; ok {
+		m.mu.Unlock()
+		return nil, ErrClientExists
+	}
+	// reserve the application ID while the client is being created
+	m.clients[*id] = nil
+	m.mu.Unlock()
+
+	client, err := m.newClient(ctx, *id, token, opts)
+
+	m.mu.Lock()
+	if err != nil {
+		delete(m.clients, *id)
+		m.mu.Unlock()
+		return nil, err
+	}
+	if m.closed {
+		m.mu.Unlock()
+		client.Close(ctx)
+		return nil, ErrClientManagerClosed
+	}
+	m.clients[*id] = &managedClient{client: client, startedAt: time.Now()}
+	m.mu.Unlock()
+	return client, nil
+}
+
+func (m *clientManagerImpl) newClient(ctx context.Context, applicationID snowflake.ID, token string, opts []ConfigOpt) (*Client, error) {
+	logger := m.config.Logger.With(slog.Any("application_id", applicationID))
+	opts = slices.Concat([]ConfigOpt{
+		WithLogger(logger),
+		WithRestClientConfigOpts(rest.WithSharedHTTPClient(m.config.HTTPClient)),
+	}, m.config.ConfigOpts, opts)
+
+	client, err := m.factory(token, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case client.HasGateway():
+		err = client.OpenGateway(ctx)
+	case client.HasShardManager():
+		err = client.OpenShardManager(ctx)
+	default:
+		err = client.StartModules(ctx)
+	}
+	if err != nil {
+		client.Close(ctx)
+		return nil, err
+	}
+	logger.Debug("started client")
+	return client, nil
+}
+
+func (m *clientManagerImpl) Remove(ctx context.Context, applicationID snowflake.ID) bool {
+	m.mu.Lock()
+	mc, ok := m.clients[applicationID]
+	if !ok || mc == nil {
+		m.mu.Unlock()
+		return false
+	}
+	delete(m.clients, applicationID)
+	m.mu.Unlock()
+
+	mc.client.Close(ctx)
+	return true
+}
+
+func (m *clientManagerImpl) Client(applicationID snowflake.ID) (*Client, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mc, ok := m.clients[applicationID]
+	if !ok || mc == nil {
+		return nil, false
+	}
+	return mc.client, true
+}
+
+func (m *clientManagerImpl) Clients() iter.Seq[*Client] {
+	return func(yield func(*Client) bool) {
+		for _, mc := range m.managedClients() {
+			if !yield(mc.client) {
+				return
+			}
+		}
+	}
+}
+
+func (m *clientManagerImpl) managedClients() []*managedClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clients := make([]*managedClient, 0, len(m.clients))
+	for _, mc := range m.clients {
+		if mc != nil {
+			clients = append(clients, mc)
+		}
+	}
+	return clients
+}
+
+func (m *clientManagerImpl) Health() ClientManagerHealth {
+	var health ClientManagerHealth
+	for _, mc := range m.managedClients() {
+		clientHealth := ClientHealth{
+			ApplicationID: mc.client.ApplicationID,
+			StartedAt:     mc.startedAt,
+			Guilds:        mc.client.Caches.GuildsLen(),
+			CacheStats:    mc.client.Caches.Stats(),
+		}
+		addShard := func(shard gateway.Gateway) {
+			clientHealth.Shards = append(clientHealth.Shards, ShardHealth{
+				ShardID: shard.ShardID(),
+				Status:  shard.Status(),
+				Latency: shard.Latency(),
+			})
+			if shard.Status() == gateway.StatusReady {
+				health.ShardsReady++
+			}
+			health.Shards++
+		}
+		if mc.client.HasGateway() {
+			addShard(mc.client.Gateway)
+		}
+		if mc.client.HasShardManager() {
+			for shard := range mc.client.ShardManager.Shards() {
+				addShard(shard)
+			}
+		}
+
+		health.Guilds += clientHealth.Guilds
+		health.Clients = append(health.Clients, clientHealth)
+	}
+	return health
+}
+
+func (m *clientManagerImpl) Close(ctx context.Context) {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, mc := range m.managedClients() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mc.client.Close(ctx)
+		}()
+	}
+	wg.Wait()
+	// the rest.Client(s) don't close the idle connections of the shared http.Client, so they are closed once all Client(s) are closed
+	m.config.HTTPClient.CloseIdleConnections()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients = make(map[snowflake.ID]*managedClient)
+}
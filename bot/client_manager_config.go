@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+func defaultClientManagerConfig() clientManagerConfig {
+	return clientManagerConfig{
+		Logger:     slog.Default(),
+		HTTPClient: &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+type clientManagerConfig struct {
+	Logger     *slog.Logger
+	HTTPClient *http.Client
+	ConfigOpts []ConfigOpt
+}
+
+// ClientManagerConfigOpt is a functional option for configuring a ClientManager.
+type ClientManagerConfigOpt func(config *clientManagerConfig)
+
+func (c *clientManagerConfig) apply(opts []ClientManagerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "bot_client_manager"))
+}
+
+// WithClientManagerLogger overrides the default Logger in the clientManagerConfig.
+// Each Client gets a Logger derived from it with its application ID.
+func WithClientManagerLogger(logger *slog.Logger) ClientManagerConfigOpt {
+	return func(config *clientManagerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithClientManagerHTTPClient overrides the http.Client which is shared by the rest.Client(s) of all Client(s).
+func WithClientManagerHTTPClient(httpClient *http.Client) ClientManagerConfigOpt {
+	return func(config *clientManagerConfig) {
+		config.HTTPClient = httpClient
+	}
+}
+
+// WithClientManagerConfigOpts adds ConfigOpt(s) which are applied to every Client before the ConfigOpt(s) of the tenant passed to ClientManager.Add.
+func WithClientManagerConfigOpts(opts ...ConfigOpt) ClientManagerConfigOpt {
+	return func(config *clientManagerConfig) {
+		config.ConfigOpts = append(config.ConfigOpts, opts...)
+	}
+}
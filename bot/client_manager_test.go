@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/disgo/internal/tokenhelper"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// testTransport counts how often the idle connections of the http.Client using it were closed.
+type testTransport struct {
+	http.RoundTripper
+	closedIdle atomic.Int32
+}
+
+func (t *testTransport) CloseIdleConnections() {
+	t.closedIdle.Add(1)
+}
+
+func testToken(applicationID string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(applicationID)) + ".token"
+}
+
+// testClientFactory builds a Client without gateway whose rest.Client is configured like by BuildClient.
+func testClientFactory(token string, opts ...ConfigOpt) (*Client, error) {
+	cfg := defaultConfig(nil, nil)
+	cfg.apply(opts)
+	id, err := tokenhelper.IDFromToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		Token:         token,
+		ApplicationID: *id,
+		Logger:        cfg.Logger,
+		Rest:          rest.New(rest.NewClient(token, cfg.RestClientConfigOpts...)),
+	}, nil
+}
+
+func TestClientManager(t *testing.T) {
+	transport := &testTransport{}
+	m := NewClientManager(testClientFactory,
+		WithClientManagerLogger(slog.New(slog.DiscardHandler)),
+		WithClientManagerHTTPClient(&http.Client{Transport: transport}),
+	)
+
+	first, err := m.Add(context.Background(), testToken("1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = m.Add(context.Background(), testToken("1")); !errors.Is(err, ErrClientExists) {
+		t.Errorf("expected ErrClientExists, got %v", err)
+	}
+	if _, err = m.Add(context.Background(), testToken("2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client, ok := m.Client(first.ApplicationID); !ok || client != first {
+		t.Errorf("expected client of application %s", first.ApplicationID)
+	}
+
+	if !m.Remove(context.Background(), first.ApplicationID) {
+		t.Errorf("expected client to be removed")
+	}
+	if m.Remove(context.Background(), first.ApplicationID) {
+		t.Errorf("expected removed client to not be managed anymore")
+	}
+	if closedIdle := transport.closedIdle.Load(); closedIdle != 0 {
+		t.Errorf("expected removing a client to keep the connections of the shared http client, got %d closes", closedIdle)
+	}
+
+	var clients int
+	for range m.Clients() {
+		clients++
+	}
+	if clients != 1 {
+		t.Errorf("expected 1 client, got %d", clients)
+	}
+
+	m.Close(context.Background())
+	if closedIdle := transport.closedIdle.Load(); closedIdle != 1 {
+		t.Errorf("expected the shared http client to be closed once, got %d closes", closedIdle)
+	}
+	if _, err = m.Add(context.Background(), testToken("3")); !errors.Is(err, ErrClientManagerClosed) {
+		t.Errorf("expected ErrClientManagerClosed, got %v", err)
+	}
+}
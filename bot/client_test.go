@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/sharding"
+)
+
+var (
+	_ GatewayEventHandler   = testGatewayEventHandler(nil)
+	_ gateway.Gateway       = (*testGateway)(nil)
+	_ sharding.ShardManager = (*testShardManager)(nil)
+)
+
+type testGatewayEventHandler func(client *Client)
+
+func (h testGatewayEventHandler) EventType() gateway.EventType {
+	return gateway.EventTypeMessageCreate
+}
+
+func (h testGatewayEventHandler) HandleGatewayEvent(client *Client, _ int, _ int, _ gateway.EventData) {
+	h(client)
+}
+
+// testGateway is a gateway.Gateway with a resumable session which counts how often it was closed.
+type testGateway struct {
+	gateway.Gateway
+	shardID    int
+	shardCount int
+	closes     atomic.Int32
+}
+
+func (g *testGateway) ShardID() int {
+	return g.shardID
+}
+
+func (g *testGateway) ShardCount() int {
+	return g.shardCount
+}
+
+func (g *testGateway) SessionID() *string {
+	sessionID := "session"
+	return &sessionID
+}
+
+func (g *testGateway) ResumeURL() *string {
+	return nil
+}
+
+func (g *testGateway) Close(_ context.Context) {
+	g.closes.Add(1)
+}
+
+func (g *testGateway) CloseWithCode(_ context.Context, _ int, _ string) {
+	g.closes.Add(1)
+}
+
+// testShardManager is a sharding.ShardManager with a single shard which counts how often all shards were closed.
+type testShardManager struct {
+	sharding.ShardManager
+	shard  *testGateway
+	closes atomic.Int32
+}
+
+func (m *testShardManager) Shards() iter.Seq[gateway.Gateway] {
+	return func(yield func(gateway.Gateway) bool) {
+		yield(m.shard)
+	}
+}
+
+func (m *testShardManager) Close(_ context.Context) {
+	m.closes.Add(1)
+}
+
+func TestClientShutdownFromListener(t *testing.T) {
+	shardManager := &testShardManager{shard: &testGateway{shardID: 1}}
+	sessions := make(chan map[int]sharding.ShardState, 1)
+	client := &Client{
+		Logger:       slog.New(slog.DiscardHandler),
+		ShardManager: shardManager,
+		gatewaySessionSaver: func(_ context.Context, s map[int]sharding.ShardState) error {
+			sessions <- s
+			return nil
+		},
+	}
+	client.EventManager = NewEventManager(client,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithGatewayHandlers(map[gateway.EventType]GatewayEventHandler{
+			gateway.EventTypeMessageCreate: testGatewayEventHandler(func(c *Client) {
+				c.EventManager.DispatchEvent(testEvent{})
+			}),
+		}),
+		WithListenerFunc(func(testEvent) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			client.Shutdown(ctx)
+		}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.EventManager.HandleGatewayEvent(shardManager.shard, gateway.EventTypeMessageCreate, 5, nil)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Shutdown called from a listener to return")
+	}
+	if state := (<-sessions)[1]; state.SessionID != "session" || state.Sequence != 5 {
+		t.Errorf("expected session of shard 1 with sequence 5, got %+v", state)
+	}
+	if closes := shardManager.shard.closes.Load(); closes != 1 {
+		t.Errorf("expected the shard to be closed once, got %d closes", closes)
+	}
+	if closes := shardManager.closes.Load(); closes != 0 {
+		t.Errorf("expected resumable shards to not be closed again by the ShardManager, got %d closes", closes)
+	}
+}
@@ -1,9 +1,12 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
@@ -19,6 +22,7 @@ func defaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, ht
 		Logger:                 slog.Default(),
 		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler)},
 		MemberChunkingFilter:   MemberChunkingFilterNone,
+		ModuleCommandSync:      true,
 	}
 }
 
@@ -51,6 +55,18 @@ type config struct {
 
 	MemberChunkingManager MemberChunkingManager
 	MemberChunkingFilter  MemberChunkingFilter
+
+	CacheReconciler           CacheReconciler
+	CacheReconcilerConfigOpts []CacheReconcilerConfigOpt
+
+	Scheduler           Scheduler
+	SchedulerConfigOpts []SchedulerConfigOpt
+
+	Modules               []Module
+	ModuleCommandSync     bool
+	ModuleCommandGuildIDs []snowflake.ID
+
+	GatewaySessionSaver GatewaySessionSaver
 }
 
 // ConfigOpt is a type alias for a function that takes a config and is used to configure your Client.
@@ -214,6 +230,60 @@ func WithMemberChunkingFilter(memberChunkingFilter MemberChunkingFilter) ConfigO
 	}
 }
 
+// WithCacheReconciler lets you inject your own CacheReconciler.
+func WithCacheReconciler(cacheReconciler CacheReconciler) ConfigOpt {
+	return func(config *config) {
+		config.CacheReconciler = cacheReconciler
+	}
+}
+
+// WithCacheReconcilerConfigOpts lets you configure the default CacheReconciler.
+func WithCacheReconcilerConfigOpts(opts ...CacheReconcilerConfigOpt) ConfigOpt {
+	return func(config *config) {
+		config.CacheReconcilerConfigOpts = append(config.CacheReconcilerConfigOpts, opts...)
+	}
+}
+
+// WithScheduler lets you inject your own Scheduler.
+func WithScheduler(scheduler Scheduler) ConfigOpt {
+	return func(config *config) {
+		config.Scheduler = scheduler
+	}
+}
+
+// WithSchedulerConfigOpts lets you configure the default Scheduler.
+func WithSchedulerConfigOpts(opts ...SchedulerConfigOpt) ConfigOpt {
+	return func(config *config) {
+		config.SchedulerConfigOpts = append(config.SchedulerConfigOpts, opts...)
+	}
+}
+
+// WithModules adds the given Module(s) to the Client. See Module for their lifecycle.
+func WithModules(modules ...Module) ConfigOpt {
+	return func(config *config) {
+		config.Modules = append(config.Modules, modules...)
+	}
+}
+
+// WithModuleCommandSync sets whether BuildClient syncs the commands of the Module(s) and to which guilds.
+// The commands are synced globally if no guildIDs are given. Syncing replaces all existing commands of the scope, including ones not defined by a Module.
+// This is enabled globally by default and only syncs if a Module implements CommandsModule.
+// Disable it to sync the commands yourself, e.g. by passing Client.ModuleCommands to handler.DiffSyncCommands.
+func WithModuleCommandSync(sync bool, guildIDs ...snowflake.ID) ConfigOpt {
+	return func(config *config) {
+		config.ModuleCommandSync = sync
+		config.ModuleCommandGuildIDs = guildIDs
+	}
+}
+
+// WithGatewaySessionSaver saves the gateway sessions during Client.Shutdown, so they can be resumed after a restart.
+// The gateway connections are then closed with a code which keeps the sessions valid.
+func WithGatewaySessionSaver(saver GatewaySessionSaver) ConfigOpt {
+	return func(config *config) {
+		config.GatewaySessionSaver = saver
+	}
+}
+
 func WithVoiceManager(voiceManager voice.Manager) ConfigOpt {
 	return func(config *config) {
 		config.VoiceManager = voiceManager
@@ -255,11 +325,13 @@ func BuildClient(
 
 	cfg := defaultConfig(gatewayHandlers, httpHandler)
 	cfg.apply(otps)
+	cfg.applyModuleRequirements()
 
 	client := &Client{
 		Token:         token,
 		Logger:        cfg.Logger,
 		ApplicationID: *id,
+		Modules:       cfg.Modules,
 	}
 
 	if cfg.RestClient == nil {
@@ -367,5 +439,22 @@ func BuildClient(
 	}
 	client.Caches = cfg.Caches
 
+	if cfg.CacheReconciler == nil {
+		cfg.CacheReconciler = NewCacheReconciler(client, append([]CacheReconcilerConfigOpt{WithCacheReconcilerLogger(cfg.Logger)}, cfg.CacheReconcilerConfigOpts...)...)
+	}
+	client.CacheReconciler = cfg.CacheReconciler
+
+	if cfg.Scheduler == nil {
+		cfg.Scheduler = NewScheduler(client, append([]SchedulerConfigOpt{WithSchedulerLogger(cfg.Logger)}, cfg.SchedulerConfigOpts...)...)
+	}
+	client.Scheduler = cfg.Scheduler
+
+	if err = client.initModules(cfg.ModuleCommandSync, cfg.ModuleCommandGuildIDs); err != nil {
+		client.Close(context.Background())
+		return nil, err
+	}
+	// only set after the Module(s) were initialized, so closing the Client above doesn't overwrite the saved sessions
+	client.gatewaySessionSaver = cfg.GatewaySessionSaver
+
 	return client, nil
 }
@@ -2,10 +2,13 @@ package bot
 
 import (
 	"context"
+	"slices"
 	"sync"
+	"time"
 )
 
 // WaitForEvent waits for an event passing the filterFunc and then calls the actionFunc. You can cancel this function with the passed context.Context and the cancelFunc gets called then.
+// Calling it from an EventListener blocks the listener, which deadlocks with WithEventWorkers if the awaited event is dispatched to the same worker.
 func WaitForEvent[E Event](client *Client, ctx context.Context, filterFunc func(e E) bool, actionFunc func(e E), cancelFunc func()) {
 	ch, cancel := NewEventCollector(client, filterFunc)
 
@@ -44,3 +47,151 @@ func NewEventCollector[E Event](client *Client, filterFunc func(e E) bool) (<-ch
 		})
 	}
 }
+
+// CollectorEndReason describes why Collect stopped collecting.
+type CollectorEndReason int
+
+const (
+	// CollectorEndReasonLimit means the configured limit of collected events was reached.
+	CollectorEndReasonLimit CollectorEndReason = iota
+	// CollectorEndReasonTimeout means the overall timeout passed.
+	CollectorEndReasonTimeout
+	// CollectorEndReasonIdle means no event was collected within the idle timeout.
+	CollectorEndReasonIdle
+	// CollectorEndReasonDeleted means the end func matched, e.g. because the message or channel got deleted.
+	CollectorEndReasonDeleted
+	// CollectorEndReasonCanceled means the context.Context was canceled.
+	CollectorEndReasonCanceled
+)
+
+func (r CollectorEndReason) String() string {
+	switch r {
+	case CollectorEndReasonLimit:
+		return "limit"
+	case CollectorEndReasonTimeout:
+		return "timeout"
+	case CollectorEndReasonIdle:
+		return "idle"
+	case CollectorEndReasonDeleted:
+		return "deleted"
+	case CollectorEndReasonCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// Collect collects events of type E passing the filterFunc until one of the configured CollectorConfigOpt(s) ends it or the context.Context is canceled.
+// It returns the collected events in the order they were received and the CollectorEndReason.
+// The filterFunc, dispose func and end func are called from the EventManager's dispatching goroutine.
+// Collect must not be called from an EventListener unless events are dispatched asynchronously.
+func Collect[E Event](client *Client, ctx context.Context, filterFunc func(e E) bool, opts ...CollectorConfigOpt) ([]E, CollectorEndReason) {
+	cfg := defaultCollectorConfig()
+	cfg.apply(opts)
+
+	c := &collector[E]{
+		config:     cfg,
+		filterFunc: filterFunc,
+		collectCh:  make(chan struct{}, 1),
+		endCh:      make(chan CollectorEndReason, 1),
+	}
+	client.EventManager.AddEventListeners(c)
+	defer client.EventManager.RemoveEventListeners(c)
+
+	var timeoutCh <-chan time.Time
+	if cfg.Timeout > 0 {
+		timeout := time.NewTimer(cfg.Timeout)
+		defer timeout.Stop()
+		timeoutCh = timeout.C
+	}
+
+	var (
+		idle   *time.Timer
+		idleCh <-chan time.Time
+	)
+	if cfg.IdleTimeout > 0 {
+		idle = time.NewTimer(cfg.IdleTimeout)
+		defer idle.Stop()
+		idleCh = idle.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return c.end(CollectorEndReasonCanceled)
+		case <-timeoutCh:
+			return c.end(CollectorEndReasonTimeout)
+		case <-idleCh:
+			return c.end(CollectorEndReasonIdle)
+		case <-c.collectCh:
+			if idle != nil {
+				idle.Reset(cfg.IdleTimeout)
+			}
+		case reason := <-c.endCh:
+			return c.end(reason)
+		}
+	}
+}
+
+type collector[E Event] struct {
+	config     collectorConfig
+	filterFunc func(e E) bool
+	collectCh  chan struct{}
+	endCh      chan CollectorEndReason
+
+	mu        sync.Mutex
+	collected []E
+	ended     bool
+}
+
+func (c *collector[E]) OnEvent(event Event) {
+	if e, ok := c.collect(event); ok && c.config.CollectFunc != nil {
+		c.config.CollectFunc(e)
+	}
+}
+
+// collect collects, disposes or ends with the event and returns whether it was collected.
+func (c *collector[E]) collect(event Event) (E, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero E
+	if c.ended {
+		return zero, false
+	}
+
+	if e, ok := event.(E); ok && (c.filterFunc == nil || c.filterFunc(e)) {
+		c.collected = append(c.collected, e)
+		if c.config.Limit > 0 && len(c.collected) >= c.config.Limit {
+			c.signalEnd(CollectorEndReasonLimit)
+			return e, true
+		}
+		select {
+		case c.collectCh <- struct{}{}:
+		default:
+		}
+		return e, true
+	}
+
+	if c.config.DisposeFunc != nil {
+		c.collected = slices.DeleteFunc(c.collected, func(collected E) bool {
+			return c.config.DisposeFunc(event, collected)
+		})
+	}
+	if c.config.EndFunc != nil && c.config.EndFunc(event) {
+		c.signalEnd(CollectorEndReasonDeleted)
+	}
+	return zero, false
+}
+
+// signalEnd stops collecting and notifies Collect. c.mu must be held.
+func (c *collector[E]) signalEnd(reason CollectorEndReason) {
+	c.ended = true
+	c.endCh <- reason
+}
+
+func (c *collector[E]) end(reason CollectorEndReason) ([]E, CollectorEndReason) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ended = true
+	return c.collected, reason
+}
@@ -0,0 +1,93 @@
+package bot
+
+import "time"
+
+func defaultCollectorConfig() collectorConfig {
+	return collectorConfig{}
+}
+
+type collectorConfig struct {
+	Limit       int
+	Timeout     time.Duration
+	IdleTimeout time.Duration
+	DisposeFunc func(event Event, collected Event) bool
+	EndFunc     func(event Event) bool
+	CollectFunc func(event Event)
+}
+
+// CollectorConfigOpt is a functional option for configuring Collect.
+type CollectorConfigOpt func(config *collectorConfig)
+
+func (c *collectorConfig) apply(opts []CollectorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithCollectorLimit ends collecting after the given number of events were collected.
+func WithCollectorLimit(limit int) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		config.Limit = limit
+	}
+}
+
+// WithCollectorTimeout ends collecting after the given duration.
+func WithCollectorTimeout(timeout time.Duration) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WithCollectorIdleTimeout ends collecting if no event was collected within the given duration. The timeout resets on each collected event.
+func WithCollectorIdleTimeout(timeout time.Duration) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		config.IdleTimeout = timeout
+	}
+}
+
+// WithCollectorDisposeFunc removes already collected events for which the disposeFunc returns true, e.g. when the collected message got deleted.
+// The disposeFunc is called with every event which was not collected.
+// If multiple disposeFuncs are set, an event is removed if any of them returns true.
+func WithCollectorDisposeFunc(disposeFunc func(event Event, collected Event) bool) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		if prev := config.DisposeFunc; prev != nil {
+			config.DisposeFunc = func(event Event, collected Event) bool {
+				return prev(event, collected) || disposeFunc(event, collected)
+			}
+			return
+		}
+		config.DisposeFunc = disposeFunc
+	}
+}
+
+// WithCollectorEndFunc ends collecting with CollectorEndReasonDeleted if the endFunc returns true, e.g. when the message got deleted.
+// The endFunc is called with every event which was not collected.
+// If multiple endFuncs are set, collecting ends if any of them returns true.
+func WithCollectorEndFunc(endFunc func(event Event) bool) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		if prev := config.EndFunc; prev != nil {
+			config.EndFunc = func(event Event) bool {
+				return prev(event) || endFunc(event)
+			}
+			return
+		}
+		config.EndFunc = endFunc
+	}
+}
+
+// WithCollectorCollectFunc calls the collectFunc with every collected event as soon as it was collected.
+// Use it to respond to events which can't wait until collecting ended, e.g. interactions which need to be responded to within 3 seconds.
+// The collectFunc is called from the EventManager's dispatching goroutine.
+// If multiple collectFuncs are set, they are called in the order they were set.
+func WithCollectorCollectFunc(collectFunc func(event Event)) CollectorConfigOpt {
+	return func(config *collectorConfig) {
+		if prev := config.CollectFunc; prev != nil {
+			config.CollectFunc = func(event Event) {
+				prev(event)
+				collectFunc(event)
+			}
+			return
+		}
+		config.CollectFunc = collectFunc
+	}
+}
@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+// collectResult is the result of a Collect call running in the background.
+type collectResult struct {
+	collected []testEvent
+	reason    CollectorEndReason
+}
+
+// startCollect runs Collect in the background and waits until its listener was added.
+func startCollect(t *testing.T, ctx context.Context, opts ...CollectorConfigOpt) (*Client, <-chan collectResult) {
+	t.Helper()
+	client := &Client{EventManager: NewEventManager(nil, WithEventManagerLogger(slog.New(slog.DiscardHandler)))}
+
+	resultCh := make(chan collectResult, 1)
+	go func() {
+		collected, reason := Collect(client, ctx, func(e testEvent) bool {
+			return e.key == 0
+		}, opts...)
+		resultCh <- collectResult{collected: collected, reason: reason}
+	}()
+
+	m := client.EventManager.(*eventManagerImpl)
+	for {
+		m.eventListenerMu.Lock()
+		added := len(m.eventListeners) > 0
+		m.eventListenerMu.Unlock()
+		if added {
+			return client, resultCh
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func waitCollect(t *testing.T, resultCh <-chan collectResult) collectResult {
+	t.Helper()
+	select {
+	case result := <-resultCh:
+		return result
+	case <-time.After(time.Second):
+		t.Fatalf("expected collecting to end")
+		return collectResult{}
+	}
+}
+
+func sequences(events []testEvent) []int {
+	s := make([]int, 0, len(events))
+	for _, e := range events {
+		s = append(s, e.sequence)
+	}
+	return s
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []CollectorConfigOpt
+		events    []testEvent
+		cancel    bool
+		reason    CollectorEndReason
+		sequences []int
+	}{
+		{
+			name:      "limit",
+			opts:      []CollectorConfigOpt{WithCollectorLimit(2)},
+			events:    []testEvent{{sequence: 1}, {key: 1, sequence: 2}, {sequence: 3}, {sequence: 4}},
+			reason:    CollectorEndReasonLimit,
+			sequences: []int{1, 3},
+		},
+		{
+			name:      "timeout",
+			opts:      []CollectorConfigOpt{WithCollectorTimeout(20 * time.Millisecond)},
+			events:    []testEvent{{sequence: 1}},
+			reason:    CollectorEndReasonTimeout,
+			sequences: []int{1},
+		},
+		{
+			name:      "idle",
+			opts:      []CollectorConfigOpt{WithCollectorIdleTimeout(20 * time.Millisecond)},
+			reason:    CollectorEndReasonIdle,
+			sequences: []int{},
+		},
+		{
+			name: "deleted",
+			opts: []CollectorConfigOpt{WithCollectorEndFunc(func(event Event) bool {
+				return event.(testEvent).key == 2
+			})},
+			events:    []testEvent{{sequence: 1}, {key: 2}, {sequence: 3}},
+			reason:    CollectorEndReasonDeleted,
+			sequences: []int{1},
+		},
+		{
+			name: "dispose",
+			opts: []CollectorConfigOpt{
+				WithCollectorLimit(2),
+				WithCollectorDisposeFunc(func(event Event, collected Event) bool {
+					return event.(testEvent).key == 1 && event.SequenceNumber() == collected.SequenceNumber()
+				}),
+			},
+			events:    []testEvent{{sequence: 1}, {key: 1, sequence: 1}, {sequence: 2}, {sequence: 3}},
+			reason:    CollectorEndReasonLimit,
+			sequences: []int{2, 3},
+		},
+		{
+			name:      "canceled",
+			events:    []testEvent{{sequence: 1}},
+			cancel:    true,
+			reason:    CollectorEndReasonCanceled,
+			sequences: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client, resultCh := startCollect(t, ctx, tt.opts...)
+			for _, e := range tt.events {
+				client.EventManager.DispatchEvent(e)
+			}
+			if tt.cancel {
+				cancel()
+			}
+
+			result := waitCollect(t, resultCh)
+			if result.reason != tt.reason {
+				t.Errorf("expected end reason %s, got %s", tt.reason, result.reason)
+			}
+			if s := sequences(result.collected); !slices.Equal(s, tt.sequences) {
+				t.Errorf("expected collected events %v, got %v", tt.sequences, s)
+			}
+		})
+	}
+}
+
+func TestCollectCollectFunc(t *testing.T) {
+	var called []int
+	client, resultCh := startCollect(t, context.Background(),
+		WithCollectorLimit(2),
+		WithCollectorCollectFunc(func(event Event) {
+			called = append(called, event.SequenceNumber())
+		}),
+	)
+
+	client.EventManager.DispatchEvent(testEvent{sequence: 1})
+	if !slices.Equal(called, []int{1}) {
+		t.Errorf("expected collect func to be called on arrival, got %v", called)
+	}
+	client.EventManager.DispatchEvent(testEvent{key: 1, sequence: 2})
+	client.EventManager.DispatchEvent(testEvent{sequence: 3})
+
+	result := waitCollect(t, resultCh)
+	if !slices.Equal(called, sequences(result.collected)) {
+		t.Errorf("expected collect func to be called with %v, got %v", sequences(result.collected), called)
+	}
+}
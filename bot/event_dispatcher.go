@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"context"
+	"hash/maphash"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EventKeyFunc returns the key of an Event. Events with the same key are dispatched in order by the worker pool configured with WithEventWorkers.
+type EventKeyFunc func(event Event) uint64
+
+// EventKeyShard keys events by the shard they were received on. This keeps the order of the gateway per shard.
+func EventKeyShard(event Event) uint64 {
+	if e, ok := event.(interface{ ShardID() int }); ok {
+		return uint64(e.ShardID())
+	}
+	return 0
+}
+
+// EventKeyGuild keys events by their guild ID and falls back to EventKeyShard for events without a guild.
+func EventKeyGuild(event Event) uint64 {
+	if id, ok := eventSnowflakeField(event, "GuildID"); ok {
+		return uint64(id)
+	}
+	return EventKeyShard(event)
+}
+
+// EventKeyChannel keys events by their channel ID and falls back to EventKeyGuild for events without a channel.
+func EventKeyChannel(event Event) uint64 {
+	if id, ok := eventSnowflakeField(event, "ChannelID"); ok {
+		return uint64(id)
+	}
+	return EventKeyGuild(event)
+}
+
+var (
+	snowflakeType    = reflect.TypeFor[snowflake.ID]()
+	snowflakePtrType = reflect.TypeFor[*snowflake.ID]()
+)
+
+// eventSnowflakeField returns the value of the snowflake.ID or *snowflake.ID field with the given name of the event, including promoted fields.
+func eventSnowflakeField(event Event, name string) (snowflake.ID, bool) {
+	v := reflect.ValueOf(event)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	field, ok := v.Type().FieldByName(name)
+	if !ok {
+		return 0, false
+	}
+	fv, err := v.FieldByIndexErr(field.Index)
+	if err != nil {
+		return 0, false
+	}
+
+	switch fv.Type() {
+	case snowflakeType:
+		return fv.Interface().(snowflake.ID), true
+	case snowflakePtrType:
+		if fv.IsNil() {
+			return 0, false
+		}
+		return *fv.Interface().(*snowflake.ID), true
+	default:
+		return 0, false
+	}
+}
+
+// EventQueueFullPolicy decides what happens when the queue of a worker is full.
+type EventQueueFullPolicy int
+
+const (
+	// EventQueueFullPolicyBlock blocks the dispatching goroutine until there is space in the queue.
+	// This applies backpressure to the shard which received the event.
+	EventQueueFullPolicyBlock EventQueueFullPolicy = iota
+	// EventQueueFullPolicyDrop drops the event and reports it to the EventMetrics.
+	EventQueueFullPolicyDrop
+)
+
+// EventMetrics receives metrics about the worker pool configured with WithEventWorkers.
+// All methods must be safe for concurrent use and should return quickly.
+type EventMetrics interface {
+	// QueueDepth is called with the number of queued events of a worker after an event was queued or dequeued.
+	QueueDepth(worker int, depth int)
+	// ListenerLatency is called with the time an EventListener took to handle an Event.
+	ListenerLatency(event Event, listener EventListener, latency time.Duration)
+	// EventDropped is called when an Event was dropped because the queue was full.
+	EventDropped(event Event)
+}
+
+type eventDispatcher struct {
+	logger   *slog.Logger
+	keyFunc  EventKeyFunc
+	policy   EventQueueFullPolicy
+	metrics  EventMetrics
+	seed     maphash.Seed
+	queues   []chan queuedEvent
+	dispatch func(event Event, listeners []EventListener)
+	// discard is called for events which were queued but not dispatched because the dispatcher was closed.
+	discard func(event Event, listeners []EventListener)
+
+	done      chan struct{}
+	closeOnce sync.Once
+	workers   sync.WaitGroup
+}
+
+type queuedEvent struct {
+	event     Event
+	listeners []EventListener
+}
+
+func newEventDispatcher(logger *slog.Logger, workers int, queueSize int, keyFunc EventKeyFunc, policy EventQueueFullPolicy, metrics EventMetrics, dispatch func(event Event, listeners []EventListener), discard func(event Event, listeners []EventListener)) *eventDispatcher {
+	if keyFunc == nil {
+		keyFunc = EventKeyShard
+	}
+	d := &eventDispatcher{
+		logger:   logger,
+		keyFunc:  keyFunc,
+		policy:   policy,
+		metrics:  metrics,
+		seed:     maphash.MakeSeed(),
+		queues:   make([]chan queuedEvent, workers),
+		dispatch: dispatch,
+		discard:  discard,
+		done:     make(chan struct{}),
+	}
+	d.workers.Add(workers)
+	for i := range d.queues {
+		d.queues[i] = make(chan queuedEvent, queueSize)
+		go d.work(i)
+	}
+	return d
+}
+
+func (d *eventDispatcher) work(worker int) {
+	defer d.workers.Done()
+	queue := d.queues[worker]
+	for {
+		select {
+		case e := <-queue:
+			d.handle(worker, queue, e)
+		case <-d.done:
+			// handle the already queued events before stopping
+			for {
+				select {
+				case e := <-queue:
+					d.handle(worker, queue, e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (d *eventDispatcher) handle(worker int, queue chan queuedEvent, e queuedEvent) {
+	if d.metrics != nil {
+		d.metrics.QueueDepth(worker, len(queue))
+	}
+	d.dispatch(e.event, e.listeners)
+}
+
+// enqueue queues the event for its worker and returns false if it was dropped.
+// With EventQueueFullPolicyBlock it blocks until there is space in the queue or the dispatcher is closed.
+func (d *eventDispatcher) enqueue(event Event, listeners []EventListener) bool {
+	select {
+	case <-d.done:
+		d.logger.Debug("event dispatcher closed, dropping event", slog.String("event_type", reflect.TypeOf(event).String()))
+		return false
+	default:
+	}
+
+	worker := int(maphash.Comparable(d.seed, d.keyFunc(event)) % uint64(len(d.queues)))
+	queue := d.queues[worker]
+	e := queuedEvent{event: event, listeners: listeners}
+
+	switch d.policy {
+	case EventQueueFullPolicyDrop:
+		select {
+		case queue <- e:
+		default:
+			d.logger.Warn("event queue full, dropping event", slog.Int("worker", worker), slog.String("event_type", reflect.TypeOf(event).String()))
+			if d.metrics != nil {
+				d.metrics.EventDropped(event)
+			}
+			return false
+		}
+	default:
+		select {
+		case queue <- e:
+		case <-d.done:
+			d.logger.Debug("event dispatcher closed, dropping event", slog.String("event_type", reflect.TypeOf(event).String()))
+			return false
+		}
+	}
+
+	if d.metrics != nil {
+		d.metrics.QueueDepth(worker, len(queue))
+	}
+	return true
+}
+
+// close stops the workers after they handled the already queued events and waits for them until the ctx is done.
+// Blocked and later calls to enqueue drop their events. It returns false if the workers did not stop in time.
+func (d *eventDispatcher) close(ctx context.Context) bool {
+	d.closeOnce.Do(func() {
+		close(d.done)
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-stopped:
+	}
+
+	// events which were queued concurrently to closing are never handled by a worker
+	for _, queue := range d.queues {
+		for {
+			select {
+			case e := <-queue:
+				d.discard(e.event, e.listeners)
+				continue
+			default:
+			}
+			break
+		}
+	}
+	return true
+}
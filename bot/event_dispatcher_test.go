@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	key      uint64
+	sequence int
+}
+
+func (e testEvent) Client() *Client     { return nil }
+func (e testEvent) SequenceNumber() int { return e.sequence }
+
+func testEventKey(event Event) uint64 {
+	return event.(testEvent).key
+}
+
+type testEventMetrics struct {
+	dropped atomic.Int32
+}
+
+func (m *testEventMetrics) QueueDepth(int, int)                                 {}
+func (m *testEventMetrics) ListenerLatency(Event, EventListener, time.Duration) {}
+func (m *testEventMetrics) EventDropped(Event)                                  { m.dropped.Add(1) }
+
+func TestEventDispatcherOrder(t *testing.T) {
+	const (
+		keys   = 8
+		events = 100
+	)
+
+	var (
+		mu        sync.Mutex
+		sequences = make(map[uint64][]int)
+	)
+	d := newEventDispatcher(slog.New(slog.DiscardHandler), 4, 16, testEventKey, EventQueueFullPolicyBlock, nil, func(event Event, _ []EventListener) {
+		e := event.(testEvent)
+		mu.Lock()
+		defer mu.Unlock()
+		sequences[e.key] = append(sequences[e.key], e.sequence)
+	}, nil)
+
+	for i := range events {
+		for key := range uint64(keys) {
+			if !d.enqueue(testEvent{key: key, sequence: i}, nil) {
+				t.Fatalf("expected event to be queued")
+			}
+		}
+	}
+	if !d.close(context.Background()) {
+		t.Fatalf("expected workers to stop")
+	}
+
+	for key := range uint64(keys) {
+		if len(sequences[key]) != events {
+			t.Fatalf("expected %d events for key %d, got %d", events, key, len(sequences[key]))
+		}
+		for i, sequence := range sequences[key] {
+			if sequence != i {
+				t.Fatalf("expected events of key %d in order, got %v", key, sequences[key])
+			}
+		}
+	}
+}
+
+func TestEventDispatcherDrop(t *testing.T) {
+	metrics := &testEventMetrics{}
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	d := newEventDispatcher(slog.New(slog.DiscardHandler), 1, 1, testEventKey, EventQueueFullPolicyDrop, metrics, func(Event, []EventListener) {
+		started <- struct{}{}
+		<-release
+	}, nil)
+	defer d.close(context.Background())
+	defer close(release)
+
+	d.enqueue(testEvent{}, nil)
+	<-started
+	if !d.enqueue(testEvent{}, nil) {
+		t.Fatalf("expected second event to be queued")
+	}
+	if d.enqueue(testEvent{}, nil) {
+		t.Fatalf("expected third event to be dropped")
+	}
+	if dropped := metrics.dropped.Load(); dropped != 1 {
+		t.Errorf("expected 1 dropped event, got %d", dropped)
+	}
+}
+
+func TestEventDispatcherCloseWhileFull(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var discarded atomic.Int32
+	d := newEventDispatcher(slog.New(slog.DiscardHandler), 1, 1, testEventKey, EventQueueFullPolicyBlock, nil, func(Event, []EventListener) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}, func(Event, []EventListener) {
+		discarded.Add(1)
+	})
+
+	d.enqueue(testEvent{}, nil)
+	<-started
+	d.enqueue(testEvent{}, nil)
+
+	blocked := make(chan bool)
+	go func() {
+		blocked <- d.enqueue(testEvent{}, nil)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if d.close(ctx) {
+		t.Errorf("expected close to time out while the worker is blocked")
+	}
+	select {
+	case queued := <-blocked:
+		if queued {
+			t.Errorf("expected blocked event to be dropped after close")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected blocked enqueue to return after close")
+	}
+	if d.enqueue(testEvent{}, nil) {
+		t.Errorf("expected events to be dropped after close")
+	}
+
+	close(release)
+	if !d.close(context.Background()) {
+		t.Errorf("expected workers to stop")
+	}
+	if discarded.Load() != 0 {
+		t.Errorf("expected queued events to be handled, got %d discarded", discarded.Load())
+	}
+}
@@ -0,0 +1,49 @@
+package bot
+
+// EventInterceptor intercepts every Event dispatched by the EventManager before it reaches the EventListener(s).
+// Call next to pass the Event on, optionally after transforming it. Not calling next drops the Event.
+// Interceptors run on the dispatching goroutine before events are handed to the workers configured with WithEventWorkers
+// or to new goroutines with WithAsyncEventsEnabled, so they can't time EventListener(s) or see their panics.
+// Use an EventListenerInterceptor for that.
+type EventInterceptor func(event Event, next func(event Event))
+
+// EventListenerInterceptor intercepts every call of an EventListener. It runs on the goroutine which calls the EventListener,
+// so it can time the EventListener or recover and report its panics with the Event as context.
+// Call next to call the EventListener. Not calling next skips the EventListener for this Event.
+type EventListenerInterceptor func(event Event, listener EventListener, next func(event Event))
+
+// NewEventFilterInterceptor returns an EventInterceptor which only passes on events for which the filter returns true.
+func NewEventFilterInterceptor(filter func(event Event) bool) EventInterceptor {
+	return func(event Event, next func(event Event)) {
+		if filter(event) {
+			next(event)
+		}
+	}
+}
+
+// chainEventInterceptors wraps dispatch with the interceptors, the first interceptor being the outermost one.
+func chainEventInterceptors(interceptors []EventInterceptor, dispatch func(event Event)) func(event Event) {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], dispatch
+		dispatch = func(event Event) {
+			interceptor(event, next)
+		}
+	}
+	return dispatch
+}
+
+// chainEventListenerInterceptors returns a func which calls the listener through the interceptors, the first interceptor being the outermost one.
+func chainEventListenerInterceptors(interceptors []EventListenerInterceptor) func(event Event, listener EventListener) {
+	call := func(event Event, listener EventListener) {
+		listener.OnEvent(event)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], call
+		call = func(event Event, listener EventListener) {
+			interceptor(event, listener, func(event Event) {
+				next(event, listener)
+			})
+		}
+	}
+	return call
+}
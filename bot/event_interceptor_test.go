@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestEventInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) EventInterceptor {
+		return func(event Event, next func(event Event)) {
+			calls = append(calls, name)
+			next(event)
+		}
+	}
+
+	m := NewEventManager(nil,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithEventInterceptors(
+			interceptor("first"),
+			interceptor("second"),
+			NewEventFilterInterceptor(func(event Event) bool {
+				return event.SequenceNumber() > 0
+			}),
+		),
+		WithListenerFunc(func(e testEvent) {
+			calls = append(calls, "listener")
+		}),
+	)
+
+	m.DispatchEvent(testEvent{sequence: 1})
+	m.DispatchEvent(testEvent{sequence: 0})
+
+	if expected := []string{"first", "second", "listener", "first", "second"}; !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestEventInterceptorPanic(t *testing.T) {
+	m := NewEventManager(nil,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithEventInterceptors(func(Event, func(event Event)) {
+			panic("interceptor")
+		}),
+	)
+
+	// must not panic
+	m.DispatchEvent(testEvent{})
+}
+
+func TestEventListenerInterceptors(t *testing.T) {
+	var (
+		calls     []string
+		recovered any
+	)
+	m := NewEventManager(nil,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithEventListenerInterceptors(
+			func(event Event, listener EventListener, next func(event Event)) {
+				defer func() {
+					recovered = recover()
+				}()
+				calls = append(calls, "report")
+				next(event)
+			},
+			func(event Event, listener EventListener, next func(event Event)) {
+				calls = append(calls, "time")
+				next(event)
+			},
+		),
+		WithListenerFunc(func(e testEvent) {
+			calls = append(calls, "listener")
+			panic("listener")
+		}),
+	)
+
+	m.DispatchEvent(testEvent{})
+
+	if expected := []string{"report", "time", "listener"}; !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	if recovered != "listener" {
+		t.Errorf("expected the interceptor to recover the listener panic, got %v", recovered)
+	}
+}
@@ -1,8 +1,12 @@
 package bot
 
 import (
+	"context"
 	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
@@ -16,14 +20,24 @@ func NewEventManager(client *Client, opts ...EventManagerConfigOpt) EventManager
 	cfg := defaultEventManagerConfig()
 	cfg.apply(opts)
 
-	return &eventManagerImpl{
+	e := &eventManagerImpl{
 		client:             client,
 		logger:             cfg.Logger,
 		eventListeners:     cfg.EventListeners,
 		asyncEventsEnabled: cfg.AsyncEventsEnabled,
+		metrics:            cfg.EventMetrics,
 		gatewayHandlers:    cfg.GatewayHandlers,
 		httpServerHandler:  cfg.HTTPServerHandler,
+		handledSequences:   make(map[int]int),
 	}
+	e.dispatch = chainEventInterceptors(cfg.EventInterceptors, e.dispatchEvent)
+	e.callListener = chainEventListenerInterceptors(cfg.EventListenerInterceptors)
+	if cfg.EventWorkers > 0 {
+		e.dispatcher = newEventDispatcher(cfg.Logger, cfg.EventWorkers, cfg.EventQueueSize, cfg.EventKeyFunc, cfg.EventQueueFullPolicy, cfg.EventMetrics, e.dispatchToListeners, func(_ Event, listeners []EventListener) {
+			e.running.add(-len(listeners))
+		})
+	}
+	return e
 }
 
 // EventManager lets you listen for specific events triggered by raw Gateway events
@@ -40,8 +54,18 @@ type EventManager interface {
 	// HandleHTTPEvent calls the HTTPServerEventHandler for the payload
 	HandleHTTPEvent(respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate)
 
-	// DispatchEvent dispatches a new Event to the Client's EventListener(s)
+	// DispatchEvent dispatches a new Event through the EventInterceptor(s) to the Client's EventListener(s)
 	DispatchEvent(event Event)
+
+	// Drain stops handling new gateway and HTTP events and waits until all running EventListener(s) finished or the ctx is done.
+	// Events dispatched via DispatchEvent are still handled while draining.
+	// It returns the number of EventListener calls which did not finish in time.
+	// Called from a synchronous EventListener, it waits until the ctx is done as the calling listener is still running.
+	Drain(ctx context.Context) int
+
+	// HandledSequence returns the sequence number of the last gateway event which was handled for the given shard.
+	// Gateway events received while draining are not handled, so resuming from this sequence receives them again.
+	HandledSequence(shardID int) (int, bool)
 }
 
 // EventListener is used to create new EventListener to listen to events
@@ -79,6 +103,46 @@ func (l *listenerChan[E]) OnEvent(e Event) {
 	}
 }
 
+// runningCalls counts running calls, e.g. of EventListener(s), and allows waiting for them to finish.
+type runningCalls struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{}
+}
+
+func (r *runningCalls) add(delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count += delta
+	if r.count == 0 && r.idle != nil {
+		close(r.idle)
+		r.idle = nil
+	}
+}
+
+// wait waits until no calls are running or the ctx is done and returns the number of still running calls.
+func (r *runningCalls) wait(ctx context.Context) int {
+	r.mu.Lock()
+	if r.count == 0 {
+		r.mu.Unlock()
+		return 0
+	}
+	if r.idle == nil {
+		r.idle = make(chan struct{})
+	}
+	idle := r.idle
+	r.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-ctx.Done():
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.count
+	}
+}
+
 // Event the basic interface each event implement
 type Event interface {
 	Client() *Client
@@ -124,13 +188,37 @@ type eventManagerImpl struct {
 	eventListenerMu    sync.Mutex
 	eventListeners     []EventListener
 	asyncEventsEnabled bool
+	dispatch           func(event Event)
+	callListener       func(event Event, listener EventListener)
+	dispatcher         *eventDispatcher
+	metrics            EventMetrics
 	gatewayHandlers    map[gateway.EventType]GatewayEventHandler
 	httpServerHandler  HTTPServerEventHandler
+	// sequencesMu guards handledSequences instead of mu, which is held while synchronous listeners run,
+	// so HandledSequence can be called from a listener or after Drain abandoned a stuck listener
+	sequencesMu      sync.Mutex
+	handledSequences map[int]int
+	draining         atomic.Bool
+	handling         runningCalls
+	running          runningCalls
 }
 
 func (e *eventManagerImpl) HandleGatewayEvent(gateway gateway.Gateway, eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
+	// counted before checking draining, so Drain either waits for this event or it is dropped
+	e.handling.add(1)
+	defer e.handling.add(-1)
+	if e.draining.Load() {
+		e.logger.Debug("dropping gateway event while draining", slog.Any("event_type", eventType), slog.Int("shard_id", gateway.ShardID()))
+		return
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	if sequenceNumber > 0 {
+		e.sequencesMu.Lock()
+		e.handledSequences[gateway.ShardID()] = sequenceNumber
+		e.sequencesMu.Unlock()
+	}
 	if handler, ok := e.gatewayHandlers[eventType]; ok {
 		handler.HandleGatewayEvent(e.client, sequenceNumber, gateway.ShardID(), event)
 	} else {
@@ -139,6 +227,13 @@ func (e *eventManagerImpl) HandleGatewayEvent(gateway gateway.Gateway, eventType
 }
 
 func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate) {
+	e.handling.add(1)
+	defer e.handling.add(-1)
+	if e.draining.Load() {
+		e.logger.Debug("dropping http interaction while draining", slog.Any("interaction_id", event.Interaction.ID()))
+		return
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.httpServerHandler.HandleHTTPEvent(e.client, respondFunc, event)
@@ -147,33 +242,81 @@ func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, e
 func (e *eventManagerImpl) DispatchEvent(event Event) {
 	defer func() {
 		if r := recover(); r != nil {
-			e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(xdebug.Stack(3))))
-			return
+			e.logger.Error("recovered from panic while dispatching event", slog.Any("arg", r), slog.String("stack", string(xdebug.Stack(3))))
 		}
 	}()
+	e.dispatch(event)
+}
+
+func (e *eventManagerImpl) dispatchEvent(event Event) {
+	// eventListeners is copy on write, so we can safely use it after releasing the lock
 	e.eventListenerMu.Lock()
-	defer e.eventListenerMu.Unlock()
-	for _, listener := range e.eventListeners {
-		if e.asyncEventsEnabled {
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(xdebug.Stack(3))))
-						return
-					}
-				}()
-				listener.OnEvent(event)
-			}()
-			continue
+	listeners := e.eventListeners
+	e.eventListenerMu.Unlock()
+
+	e.running.add(len(listeners))
+	if e.dispatcher != nil {
+		if !e.dispatcher.enqueue(event, listeners) {
+			e.running.add(-len(listeners))
+		}
+		return
+	}
+	if e.asyncEventsEnabled {
+		for _, listener := range listeners {
+			go e.dispatchToListener(event, listener)
+		}
+		return
+	}
+	e.dispatchToListeners(event, listeners)
+}
+
+func (e *eventManagerImpl) dispatchToListeners(event Event, listeners []EventListener) {
+	for _, listener := range listeners {
+		e.dispatchToListener(event, listener)
+	}
+}
+
+// dispatchToListener calls the listener through the EventListenerInterceptor(s) and recovers from panics in it.
+func (e *eventManagerImpl) dispatchToListener(event Event, listener EventListener) {
+	defer e.running.add(-1)
+	defer func() {
+		if r := recover(); r != nil {
+			e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(xdebug.Stack(3))))
 		}
-		listener.OnEvent(event)
+	}()
+	if e.metrics == nil {
+		e.callListener(event, listener)
+		return
 	}
+	start := time.Now()
+	e.callListener(event, listener)
+	e.metrics.ListenerLatency(event, listener, time.Since(start))
+}
+
+func (e *eventManagerImpl) Drain(ctx context.Context) int {
+	e.draining.Store(true)
+	// wait for gateway and http events which are currently being handled without taking e.mu,
+	// which is held while synchronous listeners run and could be held by the listener calling Drain
+	e.handling.wait(ctx)
+
+	abandoned := e.running.wait(ctx)
+	if e.dispatcher != nil {
+		e.dispatcher.close(ctx)
+	}
+	return abandoned
+}
+
+func (e *eventManagerImpl) HandledSequence(shardID int) (int, bool) {
+	e.sequencesMu.Lock()
+	defer e.sequencesMu.Unlock()
+	sequence, ok := e.handledSequences[shardID]
+	return sequence, ok
 }
 
 func (e *eventManagerImpl) AddEventListeners(listeners ...EventListener) {
 	e.eventListenerMu.Lock()
 	defer e.eventListenerMu.Unlock()
-	e.eventListeners = append(e.eventListeners, listeners...)
+	e.eventListeners = append(slices.Clip(e.eventListeners), listeners...)
 }
 
 func (e *eventManagerImpl) RemoveEventListeners(listeners ...EventListener) {
@@ -182,7 +325,7 @@ func (e *eventManagerImpl) RemoveEventListeners(listeners ...EventListener) {
 	for _, listener := range listeners {
 		for i, l := range e.eventListeners {
 			if l == listener {
-				e.eventListeners = append(e.eventListeners[:i], e.eventListeners[i+1:]...)
+				e.eventListeners = slices.Delete(slices.Clone(e.eventListeners), i, i+1)
 				break
 			}
 		}
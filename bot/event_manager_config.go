@@ -17,6 +17,15 @@ type eventManagerConfig struct {
 	EventListeners     []EventListener
 	AsyncEventsEnabled bool
 
+	EventWorkers         int
+	EventQueueSize       int
+	EventKeyFunc         EventKeyFunc
+	EventQueueFullPolicy EventQueueFullPolicy
+	EventMetrics         EventMetrics
+	EventInterceptors    []EventInterceptor
+
+	EventListenerInterceptors []EventListenerInterceptor
+
 	GatewayHandlers   map[gateway.EventType]GatewayEventHandler
 	HTTPServerHandler HTTPServerEventHandler
 }
@@ -62,6 +71,59 @@ func WithAsyncEventsEnabled() EventManagerConfigOpt {
 	}
 }
 
+// WithEventWorkers dispatches events with a pool of workers, each with a queue of queueSize events.
+// Events with the same key (see WithEventKeyFunc) are always handled by the same worker and therefore in order.
+// This takes precedence over WithAsyncEventsEnabled.
+//
+// A listener blocks its worker while it runs. Waiting for another event in a listener, e.g. with WaitForEvent or Collect,
+// deadlocks the worker if the awaited event has the same key, which is always the case with the default EventKeyShard and a single shard.
+// Run such listeners in a new goroutine.
+func WithEventWorkers(workers int, queueSize int) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventWorkers = workers
+		config.EventQueueSize = queueSize
+	}
+}
+
+// WithEventKeyFunc sets the EventKeyFunc used to distribute events to the workers configured with WithEventWorkers.
+// Defaults to EventKeyShard.
+func WithEventKeyFunc(keyFunc EventKeyFunc) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventKeyFunc = keyFunc
+	}
+}
+
+// WithEventQueueFullPolicy sets the EventQueueFullPolicy of the workers configured with WithEventWorkers.
+// Defaults to EventQueueFullPolicyBlock.
+func WithEventQueueFullPolicy(policy EventQueueFullPolicy) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventQueueFullPolicy = policy
+	}
+}
+
+// WithEventMetrics sets the EventMetrics which receives queue depths, listener latencies and dropped events.
+func WithEventMetrics(metrics EventMetrics) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventMetrics = metrics
+	}
+}
+
+// WithEventInterceptors adds the given EventInterceptor(s) to the eventManagerConfig.
+// Interceptors are called in the order they were added.
+func WithEventInterceptors(interceptors ...EventInterceptor) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventInterceptors = append(config.EventInterceptors, interceptors...)
+	}
+}
+
+// WithEventListenerInterceptors adds the given EventListenerInterceptor(s) to the eventManagerConfig.
+// Interceptors are called in the order they were added.
+func WithEventListenerInterceptors(interceptors ...EventListenerInterceptor) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.EventListenerInterceptors = append(config.EventListenerInterceptors, interceptors...)
+	}
+}
+
 // WithGatewayHandlers overrides the default GatewayEventHandler(s) in the eventManagerConfig.
 func WithGatewayHandlers(handlers map[gateway.EventType]GatewayEventHandler) EventManagerConfigOpt {
 	return func(config *eventManagerConfig) {
@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/httpserver"
+)
+
+var _ HTTPServerEventHandler = testHTTPServerEventHandler(nil)
+
+type testHTTPServerEventHandler func(client *Client)
+
+func (h testHTTPServerEventHandler) HandleHTTPEvent(client *Client, _ httpserver.RespondFunc, _ httpserver.EventInteractionCreate) {
+	h(client)
+}
+
+func TestEventManagerDrainFromListener(t *testing.T) {
+	var (
+		m         EventManager
+		abandoned = make(chan int, 1)
+	)
+	m = NewEventManager(nil,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithHTTPServerHandler(testHTTPServerEventHandler(func(*Client) {
+			m.DispatchEvent(testEvent{})
+		})),
+		WithListenerFunc(func(testEvent) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			abandoned <- m.Drain(ctx)
+		}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.HandleHTTPEvent(nil, httpserver.EventInteractionCreate{})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Drain called from a listener to return after the ctx is done")
+	}
+	if n := <-abandoned; n != 1 {
+		t.Errorf("expected the calling listener to be abandoned, got %d", n)
+	}
+}
+
+func TestEventManagerDrainWaitsForHandling(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	m := NewEventManager(nil,
+		WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		WithHTTPServerHandler(testHTTPServerEventHandler(func(*Client) {
+			close(started)
+			<-release
+		})),
+	)
+
+	go m.HandleHTTPEvent(nil, httpserver.EventInteractionCreate{})
+	<-started
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		m.Drain(context.Background())
+	}()
+
+	select {
+	case <-drained:
+		t.Fatalf("expected Drain to wait for the event being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Drain to return after the event was handled")
+	}
+}
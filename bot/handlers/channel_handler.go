@@ -59,6 +59,7 @@ func gatewayHandlerChannelUpdate(client *bot.Client, sequenceNumber int, shardID
 
 func gatewayHandlerChannelDelete(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventChannelDelete) {
 	client.Caches.RemoveChannel(event.ID())
+	client.Caches.RemoveMessageHistoryByChannelID(event.ID())
 
 	client.EventManager.DispatchEvent(&events.GuildChannelDelete{
 		GenericGuildChannel: &events.GenericGuildChannel{
@@ -145,6 +145,7 @@ func gatewayHandlerGuildDelete(client *bot.Client, sequenceNumber int, shardID i
 	client.Caches.RemoveGuildScheduledEventsByGuildID(event.ID)
 	client.Caches.RemoveGuildSoundboardSoundsByGuildID(event.ID)
 	client.Caches.RemoveMessagesByGuildID(event.ID)
+	client.Caches.RemoveMessageHistoryByGuildID(event.ID)
 
 	genericGuildEvent := &events.GenericGuild{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"reflect"
+	"slices"
+	"time"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/bot"
@@ -65,19 +69,28 @@ func gatewayHandlerMessageCreate(client *bot.Client, sequenceNumber int, shardID
 }
 
 func gatewayHandlerMessageUpdate(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageUpdate) {
-	oldMessage, _ := client.Caches.Message(event.ChannelID, event.ID)
-	client.Caches.AddMessage(event.Message)
+	message := event.Message
+	oldMessage, ok := client.Caches.Message(event.ChannelID, event.ID)
+	if ok {
+		message = mergeMessageUpdate(oldMessage, message)
+		if isMessageRevision(oldMessage, message) {
+			client.Caches.AddMessageRevision(oldMessage)
+		}
+	}
+	client.Caches.AddMessage(message)
+	revisions := client.Caches.MessageRevisions(event.ChannelID, event.ID)
 
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 	client.EventManager.DispatchEvent(&events.MessageUpdate{
 		GenericMessage: &events.GenericMessage{
 			GenericEvent: genericEvent,
 			MessageID:    event.ID,
-			Message:      event.Message,
+			Message:      message,
 			ChannelID:    event.ChannelID,
 			GuildID:      event.GuildID,
 		},
 		OldMessage: oldMessage,
+		Revisions:  revisions,
 	})
 
 	if event.GuildID == nil {
@@ -85,25 +98,69 @@ func gatewayHandlerMessageUpdate(client *bot.Client, sequenceNumber int, shardID
 			GenericDMMessage: &events.GenericDMMessage{
 				GenericEvent: genericEvent,
 				MessageID:    event.ID,
-				Message:      event.Message,
+				Message:      message,
 				ChannelID:    event.ChannelID,
 			},
 			OldMessage: oldMessage,
+			Revisions:  revisions,
 		})
 	} else {
 		client.EventManager.DispatchEvent(&events.GuildMessageUpdate{
 			GenericGuildMessage: &events.GenericGuildMessage{
 				GenericEvent: genericEvent,
 				MessageID:    event.ID,
-				Message:      event.Message,
+				Message:      message,
 				ChannelID:    event.ChannelID,
 				GuildID:      *event.GuildID,
 			},
 			OldMessage: oldMessage,
+			Revisions:  revisions,
 		})
 	}
 }
 
+// mergeMessageUpdate applies a partial message update, e.g. from embed unfurling, to the cached message.
+// Full message updates are returned as is.
+func mergeMessageUpdate(oldMessage discord.Message, message discord.Message) discord.Message {
+	if message.Author.ID != 0 {
+		return message
+	}
+
+	merged := oldMessage
+	merged.Embeds = message.Embeds
+	if message.Content != "" {
+		merged.Content = message.Content
+	}
+	if message.EditedTimestamp != nil {
+		merged.EditedTimestamp = message.EditedTimestamp
+	}
+	if message.Attachments != nil {
+		merged.Attachments = message.Attachments
+	}
+	if message.Components != nil {
+		merged.Components = message.Components
+	}
+	if message.Flags != 0 {
+		merged.Flags = message.Flags
+	}
+	return merged
+}
+
+// isMessageRevision returns whether the content, embeds or attachments of the message were edited.
+// Embed updates without an edit, e.g. link previews being resolved, are not revisions.
+func isMessageRevision(oldMessage discord.Message, message discord.Message) bool {
+	if oldMessage.Content != message.Content {
+		return true
+	}
+	if !slices.EqualFunc(oldMessage.Attachments, message.Attachments, func(a discord.Attachment, b discord.Attachment) bool {
+		return a.ID == b.ID
+	}) {
+		return true
+	}
+	edited := message.EditedTimestamp != nil && (oldMessage.EditedTimestamp == nil || !oldMessage.EditedTimestamp.Equal(*message.EditedTimestamp))
+	return edited && !reflect.DeepEqual(oldMessage.Embeds, message.Embeds)
+}
+
 func gatewayHandlerMessageDelete(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageDelete) {
 	handleMessageDelete(client, sequenceNumber, shardID, event.ID, event.ChannelID, event.GuildID)
 }
@@ -117,7 +174,11 @@ func gatewayHandlerMessageDeleteBulk(client *bot.Client, sequenceNumber int, sha
 func handleMessageDelete(client *bot.Client, sequenceNumber int, shardID int, messageID snowflake.ID, channelID snowflake.ID, guildID *snowflake.ID) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	message, _ := client.Caches.RemoveMessage(channelID, messageID)
+	message, ok := client.Caches.RemoveMessage(channelID, messageID)
+	var revisions []discord.Message
+	if ok {
+		revisions = client.Caches.AddMessageTombstone(message, time.Now()).Revisions
+	}
 
 	if channel, ok := client.Caches.GuildThread(channelID); ok {
 		if channel.MessageCount > 0 {
@@ -134,6 +195,7 @@ func handleMessageDelete(client *bot.Client, sequenceNumber int, shardID int, me
 			ChannelID:    channelID,
 			GuildID:      guildID,
 		},
+		Revisions: revisions,
 	})
 
 	if guildID == nil {
@@ -144,6 +206,7 @@ func handleMessageDelete(client *bot.Client, sequenceNumber int, shardID int, me
 				Message:      message,
 				ChannelID:    channelID,
 			},
+			Revisions: revisions,
 		})
 	} else {
 		client.EventManager.DispatchEvent(&events.GuildMessageDelete{
@@ -154,6 +217,7 @@ func handleMessageDelete(client *bot.Client, sequenceNumber int, shardID int, me
 				ChannelID:    channelID,
 				GuildID:      *guildID,
 			},
+			Revisions: revisions,
 		})
 	}
 }
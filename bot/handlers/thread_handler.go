@@ -45,6 +45,7 @@ func gatewayHandlerThreadDelete(client *bot.Client, sequenceNumber int, shardID
 		thread, _ = channel.(discord.GuildThread)
 	}
 	client.Caches.RemoveThreadMembersByThreadID(event.ID)
+	client.Caches.RemoveMessageHistoryByChannelID(event.ID)
 
 	client.EventManager.DispatchEvent(&events.ThreadDelete{
 		GenericThread: &events.GenericThread{
@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/sharding"
+)
+
+// Module is a self-contained feature of a bot which is composed into the Client with WithModules.
+// A Module can additionally declare what it needs by implementing IntentsModule, CacheFlagsModule, ListenersModule and CommandsModule.
+type Module interface {
+	// Name returns the name of the Module used in logs and errors.
+	Name() string
+
+	// Init is called by BuildClient after the Client was built, in the order the Module(s) were added.
+	// Listeners are registered and commands are synced after all Module(s) were initialized.
+	// If a Module fails to initialize or the commands fail to sync, the initialized Module(s) are stopped in reverse order and the Client is closed again.
+	Init(client *Client) error
+
+	// Start is called when the Client is opened via Client.OpenGateway or Client.OpenShardManager or explicitly via Client.StartModules.
+	// Long-running work like background goroutines should be started here.
+	Start(ctx context.Context) error
+
+	// Stop is called by Client.Close in the reverse order the Module(s) were started, before any connection is closed.
+	// It is also called without a prior Start to clean up an initialized Module when BuildClient fails.
+	Stop(ctx context.Context)
+}
+
+// IntentsModule is a Module which requires gateway.Intents. They are added to the default gateway.Gateway or sharding.ShardManager.
+type IntentsModule interface {
+	Module
+	Intents() gateway.Intents
+}
+
+// CacheFlagsModule is a Module which requires cache.Flags. They are added to the default cache.Caches.
+type CacheFlagsModule interface {
+	Module
+	CacheFlags() cache.Flags
+}
+
+// ListenersModule is a Module which registers EventListener(s), e.g. a handler.Router with its routes.
+type ListenersModule interface {
+	Module
+	Listeners() []EventListener
+}
+
+// CommandsModule is a Module which defines application commands.
+// The commands of all Module(s) are synced by BuildClient, see WithModuleCommandSync.
+type CommandsModule interface {
+	Module
+	Commands() []discord.ApplicationCommandCreate
+}
+
+// applyModuleRequirements adds the intents and cache flags declared by the Module(s) to the config.
+// Injected gateway.Gateway(s), sharding.ShardManager(s) and cache.Caches are not changed.
+func (c *config) applyModuleRequirements() {
+	var (
+		intents    gateway.Intents
+		cacheFlags cache.Flags
+	)
+	for _, module := range c.Modules {
+		if m, ok := module.(IntentsModule); ok {
+			intents = intents.Add(m.Intents())
+		}
+		if m, ok := module.(CacheFlagsModule); ok {
+			cacheFlags = cacheFlags.Add(m.CacheFlags())
+		}
+	}
+
+	if intents != gateway.IntentsNone {
+		if len(c.GatewayConfigOpts) > 0 {
+			c.GatewayConfigOpts = append(c.GatewayConfigOpts, gateway.WithIntents(intents))
+		}
+		if len(c.ShardManagerConfigOpts) > 0 {
+			c.ShardManagerConfigOpts = append(c.ShardManagerConfigOpts, sharding.WithGatewayConfigOpts(gateway.WithIntents(intents)))
+		}
+	}
+	if cacheFlags != cache.FlagsNone {
+		c.CacheConfigOpts = append(c.CacheConfigOpts, cache.WithCaches(cacheFlags))
+	}
+}
+
+// initModules initializes the Module(s), registers their listeners and syncs their commands.
+// If a Module fails to initialize or the commands fail to sync, the initialized Module(s) are stopped in reverse order.
+func (c *Client) initModules(syncCommands bool, guildIDs []snowflake.ID) error {
+	var listeners []EventListener
+	for i, module := range c.Modules {
+		if err := module.Init(c); err != nil {
+			c.cleanupModules(c.Modules[:i])
+			return fmt.Errorf("error while initializing module %s: %w", module.Name(), err)
+		}
+		if m, ok := module.(ListenersModule); ok {
+			listeners = append(listeners, m.Listeners()...)
+		}
+	}
+
+	if syncCommands {
+		if err := c.syncModuleCommands(guildIDs); err != nil {
+			c.cleanupModules(c.Modules)
+			return err
+		}
+	}
+	c.EventManager.AddEventListeners(listeners...)
+	return nil
+}
+
+// cleanupModules stops the given initialized Module(s) in reverse order.
+func (c *Client) cleanupModules(modules []Module) {
+	for i := len(modules) - 1; i >= 0; i-- {
+		modules[i].Stop(context.Background())
+		c.Logger.Debug("cleaned up module", slog.String("module", modules[i].Name()))
+	}
+}
+
+// ModuleCommands returns the commands of all CommandsModule(s) in the order the Module(s) were added.
+func (c *Client) ModuleCommands() []discord.ApplicationCommandCreate {
+	var commands []discord.ApplicationCommandCreate
+	for _, module := range c.Modules {
+		if m, ok := module.(CommandsModule); ok {
+			commands = append(commands, m.Commands()...)
+		}
+	}
+	return commands
+}
+
+// syncModuleCommands sets the commands of the Module(s) for the given guilds or globally if no guildIDs are given.
+// Nothing is synced if no Module defines commands, so existing commands of bots without CommandsModule(s) are kept.
+func (c *Client) syncModuleCommands(guildIDs []snowflake.ID) error {
+	var defined bool
+	for _, module := range c.Modules {
+		if _, ok := module.(CommandsModule); ok {
+			defined = true
+			break
+		}
+	}
+	if !defined {
+		return nil
+	}
+
+	commands := c.ModuleCommands()
+	if len(guildIDs) == 0 {
+		if _, err := c.Rest.SetGlobalCommands(c.ApplicationID, commands); err != nil {
+			return fmt.Errorf("error while syncing module commands: %w", err)
+		}
+		return nil
+	}
+	for _, guildID := range guildIDs {
+		if _, err := c.Rest.SetGuildCommands(c.ApplicationID, guildID, commands); err != nil {
+			return fmt.Errorf("error while syncing module commands in guild %s: %w", guildID, err)
+		}
+	}
+	return nil
+}
+
+// StartModules starts all Module(s) which were not started yet in the order they were added.
+// If a Module fails to start, the already started Module(s) are stopped again and the error is returned.
+// This is called by Client.OpenGateway and Client.OpenShardManager and only needs to be called for bots without a gateway.
+func (c *Client) StartModules(ctx context.Context) error {
+	c.modulesMu.Lock()
+	defer c.modulesMu.Unlock()
+
+	for _, module := range c.Modules[c.startedModules:] {
+		if err := module.Start(ctx); err != nil {
+			c.stopModules(ctx)
+			return fmt.Errorf("error while starting module %s: %w", module.Name(), err)
+		}
+		c.startedModules++
+		c.Logger.Debug("started module", slog.String("module", module.Name()))
+	}
+	return nil
+}
+
+// stopModules stops all started Module(s) in reverse order. c.modulesMu must be held.
+func (c *Client) stopModules(ctx context.Context) {
+	for i := c.startedModules - 1; i >= 0; i-- {
+		c.Modules[i].Stop(ctx)
+		c.Logger.Debug("stopped module", slog.String("module", c.Modules[i].Name()))
+	}
+	c.startedModules = 0
+}
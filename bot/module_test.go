@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var (
+	_ Module         = (*testModule)(nil)
+	_ CommandsModule = (*testCommandsModule)(nil)
+)
+
+// testModule records its lifecycle calls in the shared calls.
+type testModule struct {
+	name    string
+	calls   *[]string
+	initErr error
+}
+
+func (m *testModule) Name() string {
+	return m.name
+}
+
+func (m *testModule) Init(_ *Client) error {
+	*m.calls = append(*m.calls, "init "+m.name)
+	return m.initErr
+}
+
+func (m *testModule) Start(_ context.Context) error {
+	*m.calls = append(*m.calls, "start "+m.name)
+	return nil
+}
+
+func (m *testModule) Stop(_ context.Context) {
+	*m.calls = append(*m.calls, "stop "+m.name)
+}
+
+type testCommandsModule struct {
+	*testModule
+}
+
+func (m testCommandsModule) Commands() []discord.ApplicationCommandCreate {
+	return []discord.ApplicationCommandCreate{discord.SlashCommandCreate{Name: m.name, Description: m.name}}
+}
+
+func TestModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		initErr error
+		syncErr bool
+		calls   []string
+		synced  bool
+	}{
+		{
+			name:   "lifecycle",
+			calls:  []string{"init a", "init b", "init c", "start a", "start b", "start c", "stop c", "stop b", "stop a"},
+			synced: true,
+		},
+		{
+			name:    "init failure",
+			initErr: errors.New("init failed"),
+			calls:   []string{"init a", "init b", "stop a"},
+		},
+		{
+			name:    "sync failure",
+			syncErr: true,
+			calls:   []string{"init a", "init b", "init c", "stop c", "stop b", "stop a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls  []string
+				synced bool
+			)
+			responses := map[*rest.Endpoint]func() any{}
+			if !tt.syncErr {
+				responses[rest.SetGlobalCommands] = func() any {
+					synced = true
+					return []discord.ApplicationCommand{}
+				}
+			}
+
+			client, err := BuildClient(testToken("1"), []ConfigOpt{
+				WithLogger(slog.New(slog.DiscardHandler)),
+				WithRestClient(&testRestClient{responses: responses}),
+				WithModules(
+					&testModule{name: "a", calls: &calls},
+					testCommandsModule{&testModule{name: "b", calls: &calls, initErr: tt.initErr}},
+					&testModule{name: "c", calls: &calls},
+				),
+			}, nil, nil, "", "", "", "")
+			if tt.initErr != nil || tt.syncErr {
+				if err == nil {
+					t.Fatalf("expected BuildClient to fail")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("failed to build client: %v", err)
+				}
+				if err = client.StartModules(context.Background()); err != nil {
+					t.Fatalf("failed to start modules: %v", err)
+				}
+				client.Close(context.Background())
+			}
+
+			if !slices.Equal(calls, tt.calls) {
+				t.Errorf("expected calls %v, got %v", tt.calls, calls)
+			}
+			if synced != tt.synced {
+				t.Errorf("expected commands synced to be %t, got %t", tt.synced, synced)
+			}
+		})
+	}
+}
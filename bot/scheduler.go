@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/internal/xdebug"
+)
+
+var _ Scheduler = (*schedulerImpl)(nil)
+
+var (
+	// ErrJobExists is returned by Scheduler.Schedule when a Job with the same name is already scheduled.
+	ErrJobExists = errors.New("job with this name is already scheduled")
+	// ErrSchedulerClosed is returned by Scheduler.Schedule when the Scheduler was closed.
+	ErrSchedulerClosed = errors.New("scheduler closed")
+	// ErrJobMissingTrigger is returned by Scheduler.Schedule when the Job has no Trigger.
+	ErrJobMissingTrigger = errors.New("job has no trigger")
+	// ErrJobMissingRun is returned by Scheduler.Schedule when the Job has no Run func.
+	ErrJobMissingRun = errors.New("job has no run func")
+)
+
+// JobScope decides where and how often a Job runs in a sharded deployment.
+type JobScope int
+
+const (
+	// JobScopeGlobal runs the Job exactly once globally on the process which owns shard 0.
+	JobScopeGlobal JobScope = iota
+	// JobScopeProcess runs the Job once on every process.
+	JobScopeProcess
+	// JobScopeGuild runs the Job once per cached guild on the process which owns the shard of the guild.
+	JobScopeGuild
+)
+
+// JobRun is passed to Job.Run.
+type JobRun struct {
+	Client *Client
+	// GuildID is the guild the Job runs for with JobScopeGuild and 0 otherwise.
+	GuildID snowflake.ID
+	// ScheduledAt is the time the run was scheduled at, including the jitter.
+	ScheduledAt time.Time
+}
+
+// Job is a periodic task run by the Scheduler.
+type Job struct {
+	// Name is the unique name of the Job.
+	Name string
+	// Trigger decides when the Job runs, see IntervalTrigger and CronTrigger.
+	Trigger Trigger
+	// Scope decides where and how often the Job runs.
+	Scope JobScope
+	// Jitter delays each run by a random duration up to Jitter, so jobs of multiple processes don't run at the exact same time.
+	Jitter time.Duration
+	// Run is called for each run. The ctx is canceled when the Job is unscheduled or the Scheduler is closed.
+	Run func(ctx context.Context, run JobRun) error
+}
+
+// Scheduler runs periodic Job(s) on the shards of this Client.
+// A run is skipped if the previous run of the same Job is still going.
+type Scheduler interface {
+	// Schedule schedules the Job. The Job runs until it is unscheduled or the Scheduler is closed.
+	Schedule(job Job) error
+
+	// Unschedule cancels the Job with the given name and returns whether it was scheduled.
+	Unschedule(name string) bool
+
+	// Close cancels all Job(s) and waits for running Job(s) to return until the ctx is done.
+	Close(ctx context.Context)
+}
+
+// NewScheduler returns a new Scheduler with the SchedulerConfigOpt(s) applied.
+func NewScheduler(client *Client, opts ...SchedulerConfigOpt) Scheduler {
+	cfg := defaultSchedulerConfig()
+	cfg.apply(opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &schedulerImpl{
+		client: client,
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		jobs:   make(map[string]*scheduledJob),
+	}
+}
+
+type schedulerImpl struct {
+	client *Client
+	config schedulerConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	jobs   map[string]*scheduledJob
+}
+
+type scheduledJob struct {
+	job     Job
+	cancel  context.CancelFunc
+	running atomic.Bool
+}
+
+func (s *schedulerImpl) Schedule(job Job) error {
+	if job.Trigger == nil {
+		return ErrJobMissingTrigger
+	}
+	if job.Run == nil {
+		return ErrJobMissingRun
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrSchedulerClosed
+	}
+	if _, ok := s.jobs[job.Name]; ok {
+		return ErrJobExists
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	sj := &scheduledJob{job: job, cancel: cancel}
+	s.jobs[job.Name] = sj
+
+	s.wg.Add(1)
+	go s.schedule(ctx, sj)
+	return nil
+}
+
+func (s *schedulerImpl) Unschedule(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sj, ok := s.jobs[name]
+	if !ok {
+		return false
+	}
+	sj.cancel()
+	delete(s.jobs, name)
+	return true
+}
+
+func (s *schedulerImpl) Close(ctx context.Context) {
+	s.mu.Lock()
+	s.closed = true
+	s.jobs = make(map[string]*scheduledJob)
+	s.mu.Unlock()
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.wg.Wait()
+	}()
+	select {
+	case <-ctx.Done():
+	case <-done:
+	}
+}
+
+func (s *schedulerImpl) schedule(ctx context.Context, sj *scheduledJob) {
+	defer s.wg.Done()
+	logger := s.config.Logger.With(slog.String("job", sj.job.Name))
+
+	last := time.Now()
+	for {
+		next := sj.job.Trigger.Next(last)
+		// don't catch up on runs which were missed, e.g. while the system was suspended
+		if now := time.Now(); !next.IsZero() && next.Before(now) {
+			next = sj.job.Trigger.Next(now)
+		}
+		if next.IsZero() {
+			return
+		}
+		last = next
+		if sj.job.Jitter > 0 {
+			next = next.Add(rand.N(sj.job.Jitter))
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !sj.running.CompareAndSwap(false, true) {
+			logger.Debug("skipping job run as the previous run is still going")
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer sj.running.Store(false)
+			s.run(ctx, logger, sj.job, next)
+		}()
+	}
+}
+
+func (s *schedulerImpl) run(ctx context.Context, logger *slog.Logger, job Job, scheduledAt time.Time) {
+	switch job.Scope {
+	case JobScopeGlobal:
+		if !s.client.ownsShard(0) {
+			return
+		}
+		s.runJob(ctx, logger, job, JobRun{Client: s.client, ScheduledAt: scheduledAt})
+
+	case JobScopeProcess:
+		s.runJob(ctx, logger, job, JobRun{Client: s.client, ScheduledAt: scheduledAt})
+
+	case JobScopeGuild:
+		var guildIDs []snowflake.ID
+		for guild := range s.client.Caches.Guilds() {
+			if s.client.ownsGuild(guild.ID) {
+				guildIDs = append(guildIDs, guild.ID)
+			}
+		}
+		for _, guildID := range guildIDs {
+			if ctx.Err() != nil {
+				return
+			}
+			s.runJob(ctx, logger, job, JobRun{Client: s.client, GuildID: guildID, ScheduledAt: scheduledAt})
+		}
+	}
+}
+
+func (s *schedulerImpl) runJob(ctx context.Context, logger *slog.Logger, job Job, run JobRun) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("recovered from panic in job", slog.Any("arg", r), slog.String("stack", string(xdebug.Stack(3))))
+		}
+	}()
+	if err := job.Run(ctx, run); err != nil {
+		logger.Error("error while running job", slog.Any("guild_id", run.GuildID), slog.Any("err", err))
+	}
+}
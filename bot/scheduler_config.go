@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"log/slog"
+)
+
+func defaultSchedulerConfig() schedulerConfig {
+	return schedulerConfig{
+		Logger: slog.Default(),
+	}
+}
+
+type schedulerConfig struct {
+	Logger *slog.Logger
+}
+
+// SchedulerConfigOpt is a functional option for configuring a Scheduler.
+type SchedulerConfigOpt func(config *schedulerConfig)
+
+func (c *schedulerConfig) apply(opts []SchedulerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "bot_scheduler"))
+}
+
+// WithSchedulerLogger overrides the default Logger in the schedulerConfig.
+func WithSchedulerLogger(logger *slog.Logger) SchedulerConfigOpt {
+	return func(config *schedulerConfig) {
+		config.Logger = logger
+	}
+}
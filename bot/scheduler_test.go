@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestSchedulerSchedule(t *testing.T) {
+	run := func(context.Context, JobRun) error { return nil }
+
+	tests := []struct {
+		name string
+		job  Job
+		err  error
+	}{
+		{name: "valid", job: Job{Name: "valid", Trigger: IntervalTrigger(time.Hour), Scope: JobScopeProcess, Run: run}},
+		{name: "missing trigger", job: Job{Name: "missing trigger", Run: run}, err: ErrJobMissingTrigger},
+		{name: "missing run", job: Job{Name: "missing run", Trigger: IntervalTrigger(time.Hour)}, err: ErrJobMissingRun},
+		{name: "exists", job: Job{Name: "valid", Trigger: IntervalTrigger(time.Hour), Run: run}, err: ErrJobExists},
+	}
+
+	s := NewScheduler(&Client{}, WithSchedulerLogger(slog.New(slog.DiscardHandler)))
+	defer s.Close(context.Background())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Schedule(tt.job); !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSchedulerSkipsRunningJob(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	s := NewScheduler(&Client{}, WithSchedulerLogger(slog.New(slog.DiscardHandler)))
+	defer s.Close(context.Background())
+
+	if err := s.Schedule(Job{
+		Name:    "slow",
+		Trigger: IntervalTrigger(5 * time.Millisecond),
+		Scope:   JobScopeProcess,
+		Run: func(_ context.Context, _ JobRun) error {
+			runs.Add(1)
+			<-release
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("failed to schedule job: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected runs to be skipped while the previous run is still going, got %d runs", n)
+	}
+}
+
+func TestSchedulerScopes(t *testing.T) {
+	// guild 1<<22 is on shard 1 and guild 2<<22 on shard 0 of 2 shards
+	guildIDs := []snowflake.ID{1 << 22, 2 << 22}
+
+	tests := []struct {
+		name     string
+		scope    JobScope
+		shardID  int
+		guildIDs []snowflake.ID
+	}{
+		{name: "global on shard 0", scope: JobScopeGlobal, shardID: 0, guildIDs: []snowflake.ID{0}},
+		{name: "global on shard 1", scope: JobScopeGlobal, shardID: 1},
+		{name: "guild on shard 0", scope: JobScopeGuild, shardID: 0, guildIDs: []snowflake.ID{2 << 22}},
+		{name: "guild on shard 1", scope: JobScopeGuild, shardID: 1, guildIDs: []snowflake.ID{1 << 22}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caches := cache.New(cache.WithCaches(cache.FlagGuilds))
+			for _, guildID := range guildIDs {
+				caches.AddGuild(discord.Guild{ID: guildID})
+			}
+			client := &Client{Caches: caches, Gateway: &testGateway{shardID: tt.shardID, shardCount: 2}}
+
+			var (
+				mu  sync.Mutex
+				ran = make(map[snowflake.ID]struct{})
+			)
+			s := NewScheduler(client, WithSchedulerLogger(slog.New(slog.DiscardHandler)))
+			if err := s.Schedule(Job{
+				Name:    "job",
+				Trigger: IntervalTrigger(5 * time.Millisecond),
+				Scope:   tt.scope,
+				Run: func(_ context.Context, run JobRun) error {
+					mu.Lock()
+					defer mu.Unlock()
+					ran[run.GuildID] = struct{}{}
+					return nil
+				},
+			}); err != nil {
+				t.Fatalf("failed to schedule job: %v", err)
+			}
+			time.Sleep(50 * time.Millisecond)
+			s.Close(context.Background())
+
+			mu.Lock()
+			defer mu.Unlock()
+			if got := slices.Sorted(maps.Keys(ran)); !slices.Equal(got, tt.guildIDs) {
+				t.Errorf("expected runs for guilds %v, got %v", tt.guildIDs, got)
+			}
+		})
+	}
+}
+
+func TestSchedulerCloseCancelsRunningJobs(t *testing.T) {
+	var (
+		started   = make(chan struct{})
+		startOnce sync.Once
+		canceled  atomic.Bool
+	)
+	s := NewScheduler(&Client{}, WithSchedulerLogger(slog.New(slog.DiscardHandler)))
+
+	if err := s.Schedule(Job{
+		Name:    "long",
+		Trigger: IntervalTrigger(time.Millisecond),
+		Scope:   JobScopeProcess,
+		Run: func(ctx context.Context, _ JobRun) error {
+			startOnce.Do(func() { close(started) })
+			<-ctx.Done()
+			canceled.Store(true)
+			return ctx.Err()
+		},
+	}); err != nil {
+		t.Fatalf("failed to schedule job: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatalf("expected job to run")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Close(ctx)
+	if ctx.Err() != nil {
+		t.Fatalf("expected Close to return once the running job returned")
+	}
+	if !canceled.Load() {
+		t.Errorf("expected the ctx of the running job to be canceled by Close")
+	}
+}
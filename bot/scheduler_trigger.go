@@ -0,0 +1,185 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trigger decides when a Job runs next.
+type Trigger interface {
+	// Next returns the next time after the given time the Job should run or the zero time if it should not run anymore.
+	Next(after time.Time) time.Time
+}
+
+// IntervalTrigger returns a Trigger which fires every interval.
+func IntervalTrigger(interval time.Duration) Trigger {
+	return intervalTrigger(interval)
+}
+
+type intervalTrigger time.Duration
+
+func (t intervalTrigger) Next(after time.Time) time.Time {
+	if t <= 0 {
+		return time.Time{}
+	}
+	return after.Add(time.Duration(t))
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronTrigger parses a cron expression with the fields minute, hour, day of month, month and day of week
+// and returns a Trigger which fires at the matching times in the location of the time passed to Trigger.Next.
+// Fields support *, lists (1,2), ranges (1-5) and steps (*/15, 1-30/5). Day of week 0 and 7 are both Sunday.
+// The macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported as well.
+// Like in cron, a day matches if either the day of month or the day of week matches when both are restricted.
+func CronTrigger(expr string) (Trigger, error) {
+	if macro, ok := cronMacros[strings.TrimSpace(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields but got %d", expr, len(fields))
+	}
+
+	var (
+		t   cronTrigger
+		err error
+	)
+	if t.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid cron minute field: %w", err)
+	}
+	if t.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid cron hour field: %w", err)
+	}
+	if t.dayOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid cron day of month field: %w", err)
+	}
+	if t.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid cron month field: %w", err)
+	}
+	if t.dayOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid cron day of week field: %w", err)
+	}
+	if t.dayOfWeek&(1<<7) != 0 {
+		t.dayOfWeek |= 1
+	}
+	t.anyDayOfMonth = fields[2] == "*"
+	t.anyDayOfWeek = fields[4] == "*"
+	return t, nil
+}
+
+// MustCronTrigger is like CronTrigger but panics if the expression is invalid.
+func MustCronTrigger(expr string) Trigger {
+	t, err := CronTrigger(expr)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// cronTrigger stores the allowed values of each field as bits.
+type cronTrigger struct {
+	minute        uint64
+	hour          uint64
+	dayOfMonth    uint64
+	month         uint64
+	dayOfWeek     uint64
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+func (t cronTrigger) Next(after time.Time) time.Time {
+	loc := after.Location()
+	next := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+
+	// every valid expression matches at least once within 4 years (29th of February), so give up after 5
+	limit := next.AddDate(5, 0, 0)
+	for next.Before(limit) {
+		if !hasBit(t.month, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !t.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !hasBit(t.hour, next.Hour()) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !hasBit(t.minute, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+func (t cronTrigger) matchesDay(day time.Time) bool {
+	dayOfMonth := hasBit(t.dayOfMonth, day.Day())
+	dayOfWeek := hasBit(t.dayOfWeek, int(day.Weekday()))
+	switch {
+	case t.anyDayOfMonth && t.anyDayOfWeek:
+		return true
+	case t.anyDayOfMonth:
+		return dayOfWeek
+	case t.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+func hasBit(bits uint64, i int) bool {
+	return bits&(1<<uint(i)) != 0
+}
+
+func parseCronField(field string, minValue int, maxValue int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = strconv.Atoi(startPart); err != nil {
+				return 0, fmt.Errorf("invalid value %q", startPart)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(endPart); err != nil {
+					return 0, fmt.Errorf("invalid value %q", endPart)
+				}
+			} else if hasStep {
+				end = maxValue
+			}
+		}
+		if start < minValue || end > maxValue || start > end {
+			return 0, fmt.Errorf("value %q out of range %d-%d", rangePart, minValue, maxValue)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
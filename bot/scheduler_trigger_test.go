@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronTrigger(t *testing.T) {
+	// 2024-01-15 is a Monday
+	after := time.Date(2024, time.January, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "every minute", expr: "* * * * *", want: time.Date(2024, time.January, 15, 10, 8, 0, 0, time.UTC)},
+		{name: "step", expr: "*/15 * * * *", want: time.Date(2024, time.January, 15, 10, 15, 0, 0, time.UTC)},
+		{name: "list", expr: "5,10 * * * *", want: time.Date(2024, time.January, 15, 10, 10, 0, 0, time.UTC)},
+		{name: "next hour", expr: "5 * * * *", want: time.Date(2024, time.January, 15, 11, 5, 0, 0, time.UTC)},
+		{name: "range", expr: "0 9-17/4 * * *", want: time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC)},
+		{name: "daily", expr: "@daily", want: time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "day of week", expr: "0 12 * * 5", want: time.Date(2024, time.January, 19, 12, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 0 * * 7", want: time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or week", expr: "0 0 20 * 3", want: time.Date(2024, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "yearly", expr: "@yearly", want: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "never", expr: "0 0 31 2 *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger, err := CronTrigger(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := trigger.Next(after); !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCronTrigger_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := CronTrigger(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
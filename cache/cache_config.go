@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"time"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -26,6 +28,7 @@ func defaultConfig() config {
 
 type config struct {
 	CacheFlags Flags
+	Stats      bool
 
 	SelfUserCache SelfUserCache
 
@@ -62,6 +65,11 @@ type config struct {
 	MessageCache       MessageCache
 	MessageCachePolicy Policy[discord.Message]
 
+	MessageHistoryCache        MessageHistoryCache
+	MessageHistoryMaxRevisions int
+	MessageHistoryMaxMessages  int
+	MessageHistoryRetention    time.Duration
+
 	EmojiCache       EmojiCache
 	EmojiCachePolicy Policy[discord.Emoji]
 
@@ -80,44 +88,63 @@ func (c *config) apply(opts []ConfigOpt) {
 		c.SelfUserCache = NewSelfUserCache()
 	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
+		c.GuildCache = NewGuildCache(newCache(c, "guilds", FlagGuilds, c.GuildCachePolicy, nil), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(newCache(c, "channels", FlagChannels, c.ChannelCachePolicy, discord.GuildChannel.GuildID))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(newGroupedCache(c, "stage_instances", FlagStageInstances, c.StageInstanceCachePolicy))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(newGroupedCache(c, "guild_scheduled_events", FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
 	}
 	if c.GuildSoundboardSoundCache == nil {
-		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(NewGroupedCache[discord.SoundboardSound](c.CacheFlags, FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy))
+		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(newGroupedCache(c, "guild_soundboard_sounds", FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(newGroupedCache(c, "roles", FlagRoles, c.RoleCachePolicy))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		c.MemberCache = NewMemberCache(newGroupedCache(c, "members", FlagMembers, c.MemberCachePolicy))
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(newGroupedCache(c, "thread_members", FlagThreadMembers, c.ThreadMemberCachePolicy))
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(newGroupedCache(c, "presences", FlagPresences, c.PresenceCachePolicy))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(newGroupedCache(c, "voice_states", FlagVoiceStates, c.VoiceStateCachePolicy))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		c.MessageCache = NewMessageCache(newGroupedCache(c, "messages", FlagMessages, c.MessageCachePolicy))
+	}
+	if c.MessageHistoryCache == nil {
+		c.MessageHistoryCache = NewMessageHistoryCache(c.MessageHistoryMaxRevisions, c.MessageHistoryMaxMessages, c.MessageHistoryRetention)
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(newGroupedCache(c, "emojis", FlagEmojis, c.EmojiCachePolicy))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(newGroupedCache(c, "stickers", FlagStickers, c.StickerCachePolicy))
+	}
+}
+
+func newCache[T any](c *config, name string, neededFlags Flags, policy Policy[T], groupFunc func(T) snowflake.ID) Cache[T] {
+	cache := NewCache[T](c.CacheFlags, neededFlags, policy)
+	if c.Stats {
+		return NewStatsCache(name, cache, groupFunc)
 	}
+	return cache
+}
+
+func newGroupedCache[T any](c *config, name string, neededFlags Flags, policy Policy[T]) GroupedCache[T] {
+	cache := NewGroupedCache[T](c.CacheFlags, neededFlags, policy)
+	if c.Stats {
+		return NewStatsGroupedCache(name, cache)
+	}
+	return cache
 }
 
 // WithCaches sets the Flags of the config.
@@ -127,6 +154,14 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithStats enables collecting Stats for the default caches.
+// Caches set via WithGuildCache, WithChannelCache, etc. are not affected.
+func WithStats() ConfigOpt {
+	return func(config *config) {
+		config.Stats = true
+	}
+}
+
 // WithSelfUserCache sets the SelfUserCache of the config.
 func WithSelfUserCache(cache SelfUserCache) ConfigOpt {
 	return func(config *config) {
@@ -288,6 +323,24 @@ func WithMessageCache(messageCache MessageCache) ConfigOpt {
 	}
 }
 
+// WithMessageHistory enables keeping up to maxRevisions previous revisions of up to maxMessages edited messages
+// and tombstones of deleted messages for the retention duration in the default MessageHistoryCache.
+// This requires the FlagMessages to be set.
+func WithMessageHistory(maxRevisions int, maxMessages int, retention time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.MessageHistoryMaxRevisions = maxRevisions
+		config.MessageHistoryMaxMessages = maxMessages
+		config.MessageHistoryRetention = retention
+	}
+}
+
+// WithMessageHistoryCache sets the MessageHistoryCache of the config.
+func WithMessageHistoryCache(messageHistoryCache MessageHistoryCache) ConfigOpt {
+	return func(config *config) {
+		config.MessageHistoryCache = messageHistoryCache
+	}
+}
+
 // WithEmojiCachePolicy sets the Policy[discord.Emoji] of the config.
 func WithEmojiCachePolicy(policy Policy[discord.Emoji]) ConfigOpt {
 	return func(config *config) {
@@ -0,0 +1,325 @@
+package cache
+
+import (
+	"iter"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Stats holds statistics about a single Cache or GroupedCache.
+// Counters are cumulative since Since, so rates can be derived by the caller or with PutRate and RemoveRate.
+type Stats struct {
+	// Name is the name of the cache, e.g. "members" or "messages".
+	Name string
+	// Len is the total number of entities in the cache.
+	Len int
+	// GroupLens is the number of entities per group (usually the guild or channel ID).
+	// This is nil for caches without groups.
+	GroupLens map[snowflake.ID]int
+	// Hits is the number of Get calls which found an entity.
+	Hits uint64
+	// Misses is the number of Get calls which did not find an entity.
+	Misses uint64
+	// Puts is the number of entities stored in the cache. Entities rejected by the Flags or Policy of the cache are not counted.
+	Puts uint64
+	// Removes is the number of entities removed from the cache.
+	Removes uint64
+	// ApproxBytes is a rough estimation of the memory used by the cached entities.
+	ApproxBytes int
+	// Since is the time the counters started counting.
+	Since time.Time
+}
+
+// HitRatio returns the ratio of hits to total Get calls or 0 if there were none.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// PutRate returns the average number of puts per second since Since.
+func (s Stats) PutRate() float64 {
+	return perSecond(s.Puts, s.Since)
+}
+
+// RemoveRate returns the average number of removes per second since Since.
+func (s Stats) RemoveRate() float64 {
+	return perSecond(s.Removes, s.Since)
+}
+
+func perSecond(n uint64, since time.Time) float64 {
+	elapsed := time.Since(since).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(n) / elapsed
+}
+
+// StatsProvider is implemented by caches which collect Stats.
+// Use WithStats to enable them for the default caches.
+type StatsProvider interface {
+	// Stats returns a snapshot of the current Stats.
+	// Calculating Len, GroupLens and ApproxBytes iterates over the whole cache, so this should not be called in hot paths.
+	Stats() Stats
+}
+
+type statsCounters struct {
+	name    string
+	since   time.Time
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	puts    atomic.Uint64
+	removes atomic.Uint64
+}
+
+func (c *statsCounters) get(ok bool) {
+	if ok {
+		c.hits.Add(1)
+		return
+	}
+	c.misses.Add(1)
+}
+
+func (c *statsCounters) put(ok bool) {
+	if ok {
+		c.puts.Add(1)
+	}
+}
+
+func (c *statsCounters) remove(ok bool) {
+	if ok {
+		c.removes.Add(1)
+	}
+}
+
+func (c *statsCounters) stats() Stats {
+	return Stats{
+		Name:    c.name,
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Puts:    c.puts.Load(),
+		Removes: c.removes.Load(),
+		Since:   c.since,
+	}
+}
+
+var (
+	_ Cache[any]        = (*statsCache[any])(nil)
+	_ StatsProvider     = (*statsCache[any])(nil)
+	_ GroupedCache[any] = (*statsGroupedCache[any])(nil)
+	_ StatsProvider     = (*statsGroupedCache[any])(nil)
+)
+
+// NewStatsCache wraps the given Cache and collects Stats about it.
+// The optional groupFunc is used to calculate Stats.GroupLens, e.g. the guild ID of a channel.
+func NewStatsCache[T any](name string, cache Cache[T], groupFunc func(T) snowflake.ID) Cache[T] {
+	return &statsCache[T]{
+		statsCounters: statsCounters{name: name, since: time.Now()},
+		cache:         cache,
+		groupFunc:     groupFunc,
+	}
+}
+
+type statsCache[T any] struct {
+	statsCounters
+	cache     Cache[T]
+	groupFunc func(T) snowflake.ID
+}
+
+func (c *statsCache[T]) Get(id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(id)
+	c.get(ok)
+	return entity, ok
+}
+
+func (c *statsCache[T]) Put(id snowflake.ID, entity T) {
+	c.cache.Put(id, entity)
+	// the underlying cache might reject the entity due to its Flags or Policy
+	_, ok := c.cache.Get(id)
+	c.put(ok)
+}
+
+func (c *statsCache[T]) Remove(id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Remove(id)
+	c.remove(ok)
+	return entity, ok
+}
+
+func (c *statsCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.cache.RemoveIf(func(entity T) bool {
+		ok := filterFunc(entity)
+		c.remove(ok)
+		return ok
+	})
+}
+
+func (c *statsCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *statsCache[T]) All() iter.Seq[T] {
+	return c.cache.All()
+}
+
+func (c *statsCache[T]) Stats() Stats {
+	stats := c.stats()
+	if c.groupFunc != nil {
+		stats.GroupLens = make(map[snowflake.ID]int)
+	}
+	for entity := range c.cache.All() {
+		stats.Len++
+		stats.ApproxBytes += approxSize(reflect.ValueOf(&entity).Elem())
+		if c.groupFunc != nil {
+			stats.GroupLens[c.groupFunc(entity)]++
+		}
+	}
+	return stats
+}
+
+// NewStatsGroupedCache wraps the given GroupedCache and collects Stats about it.
+func NewStatsGroupedCache[T any](name string, cache GroupedCache[T]) GroupedCache[T] {
+	return &statsGroupedCache[T]{
+		statsCounters: statsCounters{name: name, since: time.Now()},
+		cache:         cache,
+	}
+}
+
+type statsGroupedCache[T any] struct {
+	statsCounters
+	cache GroupedCache[T]
+}
+
+func (c *statsGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(groupID, id)
+	c.get(ok)
+	return entity, ok
+}
+
+func (c *statsGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	c.cache.Put(groupID, id, entity)
+	// the underlying cache might reject the entity due to its Flags or Policy
+	_, ok := c.cache.Get(groupID, id)
+	c.put(ok)
+}
+
+func (c *statsGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Remove(groupID, id)
+	c.remove(ok)
+	return entity, ok
+}
+
+func (c *statsGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	n := c.cache.GroupLen(groupID)
+	c.cache.GroupRemove(groupID)
+	c.removes.Add(uint64(n))
+}
+
+func (c *statsGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.cache.RemoveIf(func(groupID snowflake.ID, entity T) bool {
+		ok := filterFunc(groupID, entity)
+		c.remove(ok)
+		return ok
+	})
+}
+
+func (c *statsGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.cache.GroupRemoveIf(groupID, func(groupID snowflake.ID, entity T) bool {
+		ok := filterFunc(groupID, entity)
+		c.remove(ok)
+		return ok
+	})
+}
+
+func (c *statsGroupedCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *statsGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.cache.GroupLen(groupID)
+}
+
+func (c *statsGroupedCache[T]) All() iter.Seq2[snowflake.ID, T] {
+	return c.cache.All()
+}
+
+func (c *statsGroupedCache[T]) GroupAll(groupID snowflake.ID) iter.Seq[T] {
+	return c.cache.GroupAll(groupID)
+}
+
+func (c *statsGroupedCache[T]) Stats() Stats {
+	stats := c.stats()
+	stats.GroupLens = make(map[snowflake.ID]int)
+	for groupID, entity := range c.cache.All() {
+		stats.Len++
+		stats.GroupLens[groupID]++
+		stats.ApproxBytes += approxSize(reflect.ValueOf(&entity).Elem())
+	}
+	return stats
+}
+
+const approxSizeMaxDepth = 16
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// approxSize returns a rough estimation of the memory used by the given value including everything it references.
+// Shared references are counted once per reference and time.Location is not followed.
+func approxSize(v reflect.Value) int {
+	return int(v.Type().Size()) + approxIndirectSize(v, 0)
+}
+
+func approxIndirectSize(v reflect.Value, depth int) int {
+	if depth > approxSizeMaxDepth {
+		return 0
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+	case reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		return int(v.Type().Elem().Size()) + approxIndirectSize(v.Elem(), depth+1)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int(elem.Type().Size()) + approxIndirectSize(elem, depth+1)
+	case reflect.Slice:
+		size := v.Cap() * int(v.Type().Elem().Size())
+		for i := range v.Len() {
+			size += approxIndirectSize(v.Index(i), depth+1)
+		}
+		return size
+	case reflect.Array:
+		var size int
+		for i := range v.Len() {
+			size += approxIndirectSize(v.Index(i), depth+1)
+		}
+		return size
+	case reflect.Map:
+		var size int
+		iter := v.MapRange()
+		for iter.Next() {
+			size += int(v.Type().Key().Size()) + int(v.Type().Elem().Size())
+			size += approxIndirectSize(iter.Key(), depth+1) + approxIndirectSize(iter.Value(), depth+1)
+		}
+		return size
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return 0
+		}
+		var size int
+		for i := range v.NumField() {
+			size += approxIndirectSize(v.Field(i), depth+1)
+		}
+		return size
+	default:
+		return 0
+	}
+}
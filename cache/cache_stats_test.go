@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestStatsCache(t *testing.T) {
+	policy := func(role discord.Role) bool {
+		return !role.Managed
+	}
+	c := NewStatsCache("roles", NewCache[discord.Role](FlagsAll, FlagRoles, policy), func(role discord.Role) snowflake.ID {
+		return role.GuildID
+	})
+
+	c.Put(1, discord.Role{ID: 1, GuildID: 10, Name: "admin"})
+	c.Put(2, discord.Role{ID: 2, GuildID: 10})
+	c.Put(3, discord.Role{ID: 3, GuildID: 20})
+	c.Put(4, discord.Role{ID: 4, GuildID: 20, Managed: true})
+	c.Get(1)
+	c.Get(4)
+	c.Remove(2)
+	c.Remove(2)
+
+	stats := c.(StatsProvider).Stats()
+	if stats.Name != "roles" || stats.Len != 2 || stats.Puts != 3 || stats.Removes != 1 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.GroupLens[10] != 1 || stats.GroupLens[20] != 1 {
+		t.Errorf("unexpected group lens: %+v", stats.GroupLens)
+	}
+	if stats.HitRatio() != 0.5 {
+		t.Errorf("unexpected hit ratio: %f", stats.HitRatio())
+	}
+
+	emptySize := approxSize(reflect.ValueOf(discord.Role{}))
+	if stats.ApproxBytes <= 2*emptySize {
+		t.Errorf("expected approx bytes to include the role name, got %d", stats.ApproxBytes)
+	}
+}
+
+func TestStatsGroupedCache(t *testing.T) {
+	c := NewStatsGroupedCache("members", NewGroupedCache[discord.Member](FlagsNone, FlagMembers, nil))
+	c.Put(10, 1, discord.Member{})
+	if stats := c.(StatsProvider).Stats(); stats.Puts != 0 || stats.Len != 0 {
+		t.Errorf("expected rejected puts to not be counted: %+v", stats)
+	}
+
+	c = NewStatsGroupedCache("members", NewGroupedCache[discord.Member](FlagsAll, FlagMembers, nil))
+	c.Put(10, 1, discord.Member{})
+	c.Put(10, 2, discord.Member{})
+	c.Put(20, 1, discord.Member{})
+	c.Get(20, 2)
+	c.GroupRemove(10)
+
+	stats := c.(StatsProvider).Stats()
+	if stats.Len != 1 || stats.Puts != 3 || stats.Removes != 2 || stats.Misses != 1 || stats.GroupLens[20] != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestApproxSize(t *testing.T) {
+	type entity struct {
+		Name  string
+		Tags  []string
+		Owner *entity
+	}
+	empty := approxSize(reflect.ValueOf(entity{}))
+	size := approxSize(reflect.ValueOf(entity{
+		Name:  "abcd",
+		Tags:  []string{"ef"},
+		Owner: &entity{Name: "gh"},
+	}))
+	// name + tag header and bytes + owner struct and name
+	expected := empty + 4 + int(reflect.TypeFor[string]().Size()) + 2 + empty + 2
+	if size != expected {
+		t.Errorf("expected %d, got %d", expected, size)
+	}
+}
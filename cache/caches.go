@@ -819,17 +819,24 @@ type Caches interface {
 	PresenceCache
 	VoiceStateCache
 	MessageCache
+	MessageHistoryCache
 	EmojiCache
 	StickerCache
 
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
 
+	// Stats returns the Stats of all entity caches which implement StatsProvider.
+	// This requires WithStats to be set or custom caches implementing StatsProvider.
+	Stats() []Stats
+
 	// MemberPermissions returns the calculated permissions of the given member.
 	// This requires the FlagRoles to be set.
 	MemberPermissions(member discord.Member) discord.Permissions
 
 	// MemberPermissionsInChannel returns the calculated permissions of the given member in the given channel.
+	// Threads inherit the permission overwrites of their parent channel, timed out members are limited to discord.PermissionViewChannel and discord.PermissionReadMessageHistory
+	// and permissions which are implicitly denied or have no effect in the channel type are removed.
 	// This requires the FlagRoles and FlagChannels to be set.
 	MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions
 
@@ -837,6 +844,31 @@ type Caches interface {
 	// This requires the FlagRoles to be set.
 	MemberRoles(member discord.Member) []discord.Role
 
+	// MemberHighestRole returns the highest role of the given member or the @everyone role if the member has no other roles.
+	// This requires the FlagRoles to be set.
+	MemberHighestRole(member discord.Member) (discord.Role, bool)
+
+	// CanModerateMember returns a *HierarchyError if the given member is not allowed to act on the target member with the given permissions.
+	// For example, pass discord.PermissionBanMembers for bans, discord.PermissionKickMembers for kicks and discord.PermissionModerateMembers for timeouts.
+	// The guild owner can act on everyone except themselves, while everyone else needs the permissions and a higher role than the target.
+	// Members with discord.PermissionAdministrator can't be timed out by anyone, including the guild owner.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	CanModerateMember(member discord.Member, target discord.Member, permissions discord.Permissions) error
+
+	// CanManageRole returns a *HierarchyError if the given member is not allowed to assign the given role to or remove it from members.
+	// The @everyone role and roles managed by an integration are always rejected, as they can't be assigned or removed,
+	// so this is not suited to check whether a role can be edited.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	CanManageRole(member discord.Member, role discord.Role) error
+
+	// SelfCanModerateMember is like CanModerateMember with the current bot member as acting member.
+	// This requires the FlagGuilds, FlagRoles and FlagMembers to be set.
+	SelfCanModerateMember(target discord.Member, permissions discord.Permissions) error
+
+	// SelfCanManageRole is like CanManageRole with the current bot member as acting member.
+	// This requires the FlagGuilds, FlagRoles and FlagMembers to be set.
+	SelfCanManageRole(role discord.Role) error
+
 	// AudioChannelMembers returns all members which are in the given audio channel.
 	// This requires the FlagVoiceStates to be set.
 	AudioChannelMembers(channel discord.GuildAudioChannel) []discord.Member
@@ -907,6 +939,7 @@ func New(opts ...ConfigOpt) Caches {
 		presenceCache:             cfg.PresenceCache,
 		voiceStateCache:           cfg.VoiceStateCache,
 		messageCache:              cfg.MessageCache,
+		messageHistoryCache:       cfg.MessageHistoryCache,
 		emojiCache:                cfg.EmojiCache,
 		stickerCache:              cfg.StickerCache,
 	}
@@ -925,6 +958,7 @@ type (
 	presenceCache             = PresenceCache
 	voiceStateCache           = VoiceStateCache
 	messageCache              = MessageCache
+	messageHistoryCache       = MessageHistoryCache
 	emojiCache                = EmojiCache
 	stickerCache              = StickerCache
 	selfUserCache             = SelfUserCache
@@ -944,6 +978,7 @@ type cachesImpl struct {
 	presenceCache
 	voiceStateCache
 	messageCache
+	messageHistoryCache
 	emojiCache
 	stickerCache
 	selfUserCache
@@ -953,7 +988,45 @@ func (c *cachesImpl) CacheFlags() Flags {
 	return c.config.CacheFlags
 }
 
+func (c *cachesImpl) Stats() []Stats {
+	caches := []any{
+		c.GuildCache(),
+		c.ChannelCache(),
+		c.StageInstanceCache(),
+		c.GuildScheduledEventCache(),
+		c.GuildSoundboardSoundCache(),
+		c.RoleCache(),
+		c.MemberCache(),
+		c.ThreadMemberCache(),
+		c.PresenceCache(),
+		c.VoiceStateCache(),
+		c.MessageCache(),
+		c.EmojiCache(),
+		c.StickerCache(),
+	}
+
+	var stats []Stats
+	for _, cache := range caches {
+		if provider, ok := cache.(StatsProvider); ok {
+			stats = append(stats, provider.Stats())
+		}
+	}
+	return stats
+}
+
 func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
+	permissions := c.memberBasePermissions(member)
+	if permissions.Has(discord.PermissionAdministrator) {
+		return discord.PermissionsAll
+	}
+	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
+		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+	}
+	return permissions
+}
+
+// memberBasePermissions returns the guild wide permissions of the member without applying timeouts.
+func (c *cachesImpl) memberBasePermissions(member discord.Member) discord.Permissions {
 	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
 		return discord.PermissionsAll
 	}
@@ -969,52 +1042,110 @@ func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permission
 			return discord.PermissionsAll
 		}
 	}
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
-	}
 	return permissions
 }
 
+// permissionsVoiceOnly are permissions which only have an effect in audio channels.
+const permissionsVoiceOnly = discord.PermissionConnect |
+	discord.PermissionSpeak |
+	discord.PermissionStream |
+	discord.PermissionMuteMembers |
+	discord.PermissionDeafenMembers |
+	discord.PermissionMoveMembers |
+	discord.PermissionUseVAD |
+	discord.PermissionPrioritySpeaker |
+	discord.PermissionRequestToSpeak |
+	discord.PermissionUseSoundboard |
+	discord.PermissionUseExternalSounds
+
+// permissionsRequireSendMessages are permissions which are implicitly denied without the permission to send messages.
+const permissionsRequireSendMessages = discord.PermissionMentionEveryone |
+	discord.PermissionSendTTSMessages |
+	discord.PermissionAttachFiles |
+	discord.PermissionEmbedLinks
+
 func (c *cachesImpl) MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions {
-	permissions := c.MemberPermissions(member)
+	permissions := c.memberBasePermissions(member)
 	if permissions.Has(discord.PermissionAdministrator) {
 		return discord.PermissionsAll
 	}
 
-	var (
-		allow discord.Permissions
-		deny  discord.Permissions
-	)
+	// threads have no overwrites of their own and inherit them from their parent channel
+	overwrites := channel.PermissionOverwrites()
+	if thread, ok := channel.(discord.GuildThread); ok {
+		overwrites = nil
+		if parent, ok := c.Channel(*thread.ParentID()); ok {
+			overwrites = parent.PermissionOverwrites()
+		}
+	}
 
-	if overwrite, ok := channel.PermissionOverwrites().Role(channel.GuildID()); ok {
-		permissions |= overwrite.Allow
+	if overwrite, ok := overwrites.Role(channel.GuildID()); ok {
 		permissions &= ^overwrite.Deny
+		permissions |= overwrite.Allow
 	}
 
+	var (
+		allow discord.Permissions
+		deny  discord.Permissions
+	)
 	for _, roleID := range member.RoleIDs {
 		if roleID == channel.GuildID() {
 			continue
 		}
 
-		if overwrite, ok := channel.PermissionOverwrites().Role(roleID); ok {
+		if overwrite, ok := overwrites.Role(roleID); ok {
 			allow |= overwrite.Allow
 			deny |= overwrite.Deny
 		}
 	}
+	permissions &= ^deny
+	permissions |= allow
 
-	if overwrite, ok := channel.PermissionOverwrites().Member(member.User.ID); ok {
-		allow |= overwrite.Allow
-		deny |= overwrite.Deny
+	if overwrite, ok := overwrites.Member(member.User.ID); ok {
+		permissions &= ^overwrite.Deny
+		permissions |= overwrite.Allow
 	}
 
-	permissions &= ^deny
-	permissions |= allow
+	if !permissions.Has(discord.PermissionViewChannel) {
+		return discord.PermissionsNone
+	}
 
 	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
 		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
 	}
 
-	return permissions
+	return implicitChannelPermissions(channel.Type(), permissions)
+}
+
+// implicitChannelPermissions removes permissions which are implicitly denied by other missing permissions or have no effect in the given channel type.
+func implicitChannelPermissions(channelType discord.ChannelType, permissions discord.Permissions) discord.Permissions {
+	switch channelType {
+	case discord.ChannelTypeGuildCategory:
+		return permissions
+
+	case discord.ChannelTypeGuildVoice, discord.ChannelTypeGuildStageVoice:
+		if !permissions.Has(discord.PermissionConnect) {
+			permissions &= ^permissionsVoiceOnly
+		}
+		if !permissions.Has(discord.PermissionSendMessages) {
+			permissions &= ^permissionsRequireSendMessages
+		}
+		return permissions
+
+	case discord.ChannelTypeGuildNewsThread, discord.ChannelTypeGuildPublicThread, discord.ChannelTypeGuildPrivateThread:
+		permissions &= ^permissionsVoiceOnly
+		if !permissions.Has(discord.PermissionSendMessagesInThreads) {
+			permissions &= ^permissionsRequireSendMessages
+		}
+		return permissions
+
+	default:
+		permissions &= ^permissionsVoiceOnly
+		if !permissions.Has(discord.PermissionSendMessages) {
+			permissions &= ^permissionsRequireSendMessages
+		}
+		return permissions
+	}
 }
 
 func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// HierarchyReason describes why an action is not allowed by Discord's role hierarchy.
+type HierarchyReason int
+
+const (
+	// HierarchyReasonUnknownGuild means the guild is not cached so the owner could not be determined.
+	HierarchyReasonUnknownGuild HierarchyReason = iota + 1
+	// HierarchyReasonUnknownSelfMember means the bot member is not cached.
+	HierarchyReasonUnknownSelfMember
+	// HierarchyReasonMissingPermissions means the acting member is missing the required permissions.
+	HierarchyReasonMissingPermissions
+	// HierarchyReasonTargetIsSelf means the acting member and the target member are the same.
+	HierarchyReasonTargetIsSelf
+	// HierarchyReasonTargetIsOwner means the target member is the owner of the guild.
+	HierarchyReasonTargetIsOwner
+	// HierarchyReasonTargetIsAdministrator means the target member has discord.PermissionAdministrator and can't be timed out.
+	HierarchyReasonTargetIsAdministrator
+	// HierarchyReasonTargetRoleTooHigh means the highest role of the target member is higher or equal to the highest role of the acting member.
+	HierarchyReasonTargetRoleTooHigh
+	// HierarchyReasonRoleTooHigh means the role is higher or equal to the highest role of the acting member.
+	HierarchyReasonRoleTooHigh
+	// HierarchyReasonRoleManaged means the role is managed by an integration and can't be assigned or removed.
+	HierarchyReasonRoleManaged
+	// HierarchyReasonRoleEveryone means the role is the @everyone role which can't be assigned or removed.
+	HierarchyReasonRoleEveryone
+)
+
+func (r HierarchyReason) String() string {
+	switch r {
+	case HierarchyReasonUnknownGuild:
+		return "guild not found in cache"
+	case HierarchyReasonUnknownSelfMember:
+		return "self member not found in cache"
+	case HierarchyReasonMissingPermissions:
+		return "missing permissions"
+	case HierarchyReasonTargetIsSelf:
+		return "target is the acting member"
+	case HierarchyReasonTargetIsOwner:
+		return "target is the guild owner"
+	case HierarchyReasonTargetIsAdministrator:
+		return "target is an administrator"
+	case HierarchyReasonTargetRoleTooHigh:
+		return "target's highest role is higher or equal to the acting member's highest role"
+	case HierarchyReasonRoleTooHigh:
+		return "role is higher or equal to the acting member's highest role"
+	case HierarchyReasonRoleManaged:
+		return "role is managed by an integration"
+	case HierarchyReasonRoleEveryone:
+		return "role is the @everyone role"
+	default:
+		return "unknown"
+	}
+}
+
+// HierarchyError is returned when an action is not allowed by Discord's role hierarchy or permissions.
+type HierarchyError struct {
+	// Reason is why the action is not allowed.
+	Reason HierarchyReason
+	// MissingPermissions is set if Reason is HierarchyReasonMissingPermissions.
+	MissingPermissions discord.Permissions
+}
+
+func (e *HierarchyError) Error() string {
+	if e.Reason == HierarchyReasonMissingPermissions {
+		return fmt.Sprintf("action not allowed: %s: %s", e.Reason, e.MissingPermissions)
+	}
+	return fmt.Sprintf("action not allowed: %s", e.Reason)
+}
+
+// CompareRolePositions compares the positions of two roles like Discord does.
+// It returns a negative number if a is lower than b, a positive number if a is higher than b and 0 if they are the same role.
+// Roles with the same position are ordered by their ID, where the older role (lower ID) is higher.
+func CompareRolePositions(a discord.Role, b discord.Role) int {
+	if a.Position != b.Position {
+		return a.Position - b.Position
+	}
+	switch {
+	case a.ID < b.ID:
+		return 1
+	case a.ID > b.ID:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (c *cachesImpl) MemberHighestRole(member discord.Member) (discord.Role, bool) {
+	var (
+		highest discord.Role
+		found   bool
+	)
+	for _, role := range c.MemberRoles(member) {
+		if !found || CompareRolePositions(role, highest) > 0 {
+			highest = role
+			found = true
+		}
+	}
+	if found {
+		return highest, true
+	}
+	return c.Role(member.GuildID, member.GuildID)
+}
+
+// compareMemberRoles compares the highest roles of two members of the same guild.
+// Members without cached roles are treated as only having the @everyone role.
+func (c *cachesImpl) compareMemberRoles(a discord.Member, b discord.Member) int {
+	everyone := discord.Role{ID: a.GuildID, GuildID: a.GuildID}
+	aRole, ok := c.MemberHighestRole(a)
+	if !ok {
+		aRole = everyone
+	}
+	bRole, ok := c.MemberHighestRole(b)
+	if !ok {
+		bRole = everyone
+	}
+	return CompareRolePositions(aRole, bRole)
+}
+
+func (c *cachesImpl) guildOwnerID(guildID snowflake.ID) (snowflake.ID, error) {
+	guild, ok := c.Guild(guildID)
+	if !ok {
+		return 0, &HierarchyError{Reason: HierarchyReasonUnknownGuild}
+	}
+	return guild.OwnerID, nil
+}
+
+func (c *cachesImpl) checkPermissions(member discord.Member, permissions discord.Permissions) error {
+	if missing := permissions &^ c.MemberPermissions(member); missing != discord.PermissionsNone {
+		return &HierarchyError{Reason: HierarchyReasonMissingPermissions, MissingPermissions: missing}
+	}
+	return nil
+}
+
+func (c *cachesImpl) CanModerateMember(member discord.Member, target discord.Member, permissions discord.Permissions) error {
+	ownerID, err := c.guildOwnerID(member.GuildID)
+	if err != nil {
+		return err
+	}
+	if member.User.ID == target.User.ID {
+		return &HierarchyError{Reason: HierarchyReasonTargetIsSelf}
+	}
+	if target.User.ID == ownerID {
+		return &HierarchyError{Reason: HierarchyReasonTargetIsOwner}
+	}
+	// Discord rejects timeouts of administrators even from the guild owner
+	if permissions.Has(discord.PermissionModerateMembers) && c.MemberPermissions(target).Has(discord.PermissionAdministrator) {
+		return &HierarchyError{Reason: HierarchyReasonTargetIsAdministrator}
+	}
+	if member.User.ID == ownerID {
+		return nil
+	}
+	if err = c.checkPermissions(member, permissions); err != nil {
+		return err
+	}
+	if c.compareMemberRoles(member, target) <= 0 {
+		return &HierarchyError{Reason: HierarchyReasonTargetRoleTooHigh}
+	}
+	return nil
+}
+
+func (c *cachesImpl) CanManageRole(member discord.Member, role discord.Role) error {
+	ownerID, err := c.guildOwnerID(member.GuildID)
+	if err != nil {
+		return err
+	}
+	if role.ID == role.GuildID {
+		return &HierarchyError{Reason: HierarchyReasonRoleEveryone}
+	}
+	if role.Managed {
+		return &HierarchyError{Reason: HierarchyReasonRoleManaged}
+	}
+	if member.User.ID == ownerID {
+		return nil
+	}
+	if err = c.checkPermissions(member, discord.PermissionManageRoles); err != nil {
+		return err
+	}
+	highest, ok := c.MemberHighestRole(member)
+	if !ok || CompareRolePositions(highest, role) <= 0 {
+		return &HierarchyError{Reason: HierarchyReasonRoleTooHigh}
+	}
+	return nil
+}
+
+func (c *cachesImpl) SelfCanModerateMember(target discord.Member, permissions discord.Permissions) error {
+	self, ok := c.SelfMember(target.GuildID)
+	if !ok {
+		return &HierarchyError{Reason: HierarchyReasonUnknownSelfMember}
+	}
+	return c.CanModerateMember(self, target, permissions)
+}
+
+func (c *cachesImpl) SelfCanManageRole(role discord.Role) error {
+	self, ok := c.SelfMember(role.GuildID)
+	if !ok {
+		return &HierarchyError{Reason: HierarchyReasonUnknownSelfMember}
+	}
+	return c.CanManageRole(self, role)
+}
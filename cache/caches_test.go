@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	testGuildID     snowflake.ID = 1
+	testOwnerID     snowflake.ID = 2
+	testUserID      snowflake.ID = 3
+	testRoleID      snowflake.ID = 4
+	testAdminRoleID snowflake.ID = 5
+)
+
+func testChannel(t *testing.T, data string) discord.GuildChannel {
+	t.Helper()
+	var channel discord.UnmarshalChannel
+	if err := json.Unmarshal([]byte(data), &channel); err != nil {
+		t.Fatalf("failed to unmarshal channel: %v", err)
+	}
+	return channel.Channel.(discord.GuildChannel)
+}
+
+func testCaches(everyone discord.Permissions, channels ...discord.GuildChannel) Caches {
+	caches := New(WithCaches(FlagsAll))
+	caches.AddGuild(discord.Guild{ID: testGuildID, OwnerID: testOwnerID})
+	caches.AddRole(discord.Role{ID: testGuildID, GuildID: testGuildID, Permissions: everyone})
+	caches.AddRole(discord.Role{ID: testRoleID, GuildID: testGuildID})
+	caches.AddRole(discord.Role{ID: testAdminRoleID, GuildID: testGuildID, Permissions: discord.PermissionAdministrator})
+	for _, channel := range channels {
+		caches.AddChannel(channel)
+	}
+	return caches
+}
+
+func TestCaches_MemberPermissionsInChannel(t *testing.T) {
+	const (
+		base = discord.PermissionViewChannel |
+			discord.PermissionSendMessages |
+			discord.PermissionEmbedLinks |
+			discord.PermissionAttachFiles |
+			discord.PermissionReadMessageHistory |
+			discord.PermissionConnect |
+			discord.PermissionSpeak
+		textBase = base &^ (discord.PermissionConnect | discord.PermissionSpeak)
+	)
+
+	text := `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[]}`
+	timedOut := time.Now().Add(time.Hour)
+	timedOutExpired := time.Now().Add(-time.Hour)
+
+	data := []struct {
+		name     string
+		channel  string
+		parent   string
+		member   discord.Member
+		expected discord.Permissions
+	}{
+		{
+			name:     "everyone without overwrites",
+			channel:  text,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: textBase,
+		},
+		{
+			name:     "owner",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"1024","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testOwnerID}},
+			expected: discord.PermissionsAll,
+		},
+		{
+			name:     "administrator ignores overwrites",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"5","type":0,"deny":"1024","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, RoleIDs: []snowflake.ID{testAdminRoleID}},
+			expected: discord.PermissionsAll,
+		},
+		{
+			name:     "everyone deny view removes all permissions",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"1024","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: discord.PermissionsNone,
+		},
+		{
+			name:     "role allow overrides everyone deny",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"1024","allow":"0"},{"id":"4","type":0,"deny":"0","allow":"1024"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, RoleIDs: []snowflake.ID{testRoleID}},
+			expected: textBase,
+		},
+		{
+			name:     "member deny overrides role allow",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"4","type":0,"deny":"0","allow":"8192"},{"id":"3","type":1,"deny":"8192","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, RoleIDs: []snowflake.ID{testRoleID}},
+			expected: textBase,
+		},
+		{
+			name:     "missing send messages strips embed and attach",
+			channel:  `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"2048","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: discord.PermissionViewChannel | discord.PermissionReadMessageHistory,
+		},
+		{
+			name:     "voice channel keeps voice permissions",
+			channel:  `{"id":"11","type":2,"guild_id":"1","permission_overwrites":[]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: base,
+		},
+		{
+			name:     "voice channel without connect strips speak",
+			channel:  `{"id":"11","type":2,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"1048576","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: textBase,
+		},
+		{
+			name:     "thread inherits parent overwrites",
+			channel:  `{"id":"12","type":11,"guild_id":"1","parent_id":"10","owner_id":"2","thread_metadata":{}}`,
+			parent:   `{"id":"10","type":0,"guild_id":"1","permission_overwrites":[{"id":"1","type":0,"deny":"1024","allow":"0"}]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: discord.PermissionsNone,
+		},
+		{
+			name:     "thread without send messages in threads strips embed and attach",
+			channel:  `{"id":"12","type":11,"guild_id":"1","parent_id":"10","owner_id":"2","thread_metadata":{}}`,
+			parent:   text,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: discord.PermissionViewChannel | discord.PermissionSendMessages | discord.PermissionReadMessageHistory,
+		},
+		{
+			name:     "timed out member",
+			channel:  text,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, CommunicationDisabledUntil: &timedOut},
+			expected: discord.PermissionViewChannel | discord.PermissionReadMessageHistory,
+		},
+		{
+			name:     "expired timeout",
+			channel:  text,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, CommunicationDisabledUntil: &timedOutExpired},
+			expected: textBase,
+		},
+		{
+			name:     "timed out administrator",
+			channel:  text,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, RoleIDs: []snowflake.ID{testAdminRoleID}, CommunicationDisabledUntil: &timedOut},
+			expected: discord.PermissionsAll,
+		},
+		{
+			name:     "category keeps all permissions",
+			channel:  `{"id":"13","type":4,"guild_id":"1","permission_overwrites":[]}`,
+			member:   discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}},
+			expected: base,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			channel := testChannel(t, d.channel)
+			channels := []discord.GuildChannel{channel}
+			if d.parent != "" {
+				channels = append(channels, testChannel(t, d.parent))
+			}
+			caches := testCaches(base, channels...)
+
+			got := caches.MemberPermissionsInChannel(channel, d.member)
+			if got != d.expected {
+				t.Errorf("expected %s, got %s", d.expected, got)
+			}
+		})
+	}
+}
+
+func TestCaches_CanModerateMember(t *testing.T) {
+	const (
+		modRoleID  snowflake.ID = 20
+		highRoleID snowflake.ID = 21
+		sameRoleID snowflake.ID = 22
+	)
+
+	caches := testCaches(discord.PermissionViewChannel)
+	caches.AddRole(discord.Role{ID: modRoleID, GuildID: testGuildID, Position: 5, Permissions: discord.PermissionBanMembers | discord.PermissionManageRoles})
+	caches.AddRole(discord.Role{ID: highRoleID, GuildID: testGuildID, Position: 10, Permissions: discord.PermissionModerateMembers})
+	caches.AddRole(discord.Role{ID: sameRoleID, GuildID: testGuildID, Position: 5})
+
+	owner := discord.Member{GuildID: testGuildID, User: discord.User{ID: testOwnerID}}
+	mod := discord.Member{GuildID: testGuildID, User: discord.User{ID: testUserID}, RoleIDs: []snowflake.ID{modRoleID}}
+	admin := discord.Member{GuildID: testGuildID, User: discord.User{ID: 30}, RoleIDs: []snowflake.ID{testAdminRoleID}}
+	high := discord.Member{GuildID: testGuildID, User: discord.User{ID: 31}, RoleIDs: []snowflake.ID{highRoleID}}
+	same := discord.Member{GuildID: testGuildID, User: discord.User{ID: 32}, RoleIDs: []snowflake.ID{sameRoleID}}
+	plain := discord.Member{GuildID: testGuildID, User: discord.User{ID: 33}}
+
+	data := []struct {
+		name        string
+		member      discord.Member
+		target      discord.Member
+		permissions discord.Permissions
+		expected    *HierarchyReason
+	}{
+		{name: "owner on anyone", member: owner, target: high, permissions: discord.PermissionBanMembers},
+		{name: "anyone on owner", member: admin, target: owner, permissions: discord.PermissionBanMembers, expected: ptr(HierarchyReasonTargetIsOwner)},
+		{name: "self", member: mod, target: mod, permissions: discord.PermissionBanMembers, expected: ptr(HierarchyReasonTargetIsSelf)},
+		{name: "lower target", member: mod, target: plain, permissions: discord.PermissionBanMembers},
+		{name: "higher target", member: mod, target: high, permissions: discord.PermissionBanMembers, expected: ptr(HierarchyReasonTargetRoleTooHigh)},
+		{name: "same position older role wins", member: same, target: mod, permissions: discord.PermissionsNone, expected: ptr(HierarchyReasonTargetRoleTooHigh)},
+		{name: "same position newer role loses", member: mod, target: same, permissions: discord.PermissionBanMembers},
+		{name: "missing permissions", member: mod, target: plain, permissions: discord.PermissionKickMembers, expected: ptr(HierarchyReasonMissingPermissions)},
+		{name: "owner can't time out administrator", member: owner, target: admin, permissions: discord.PermissionModerateMembers, expected: ptr(HierarchyReasonTargetIsAdministrator)},
+		{name: "owner on administrator", member: owner, target: admin, permissions: discord.PermissionBanMembers},
+		{name: "administrator can't be timed out", member: high, target: admin, permissions: discord.PermissionModerateMembers, expected: ptr(HierarchyReasonTargetIsAdministrator)},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			assertHierarchyReason(t, caches.CanModerateMember(d.member, d.target, d.permissions), d.expected)
+		})
+	}
+
+	assertHierarchyReason(t, caches.CanManageRole(mod, discord.Role{ID: 40, GuildID: testGuildID, Position: 1}), nil)
+	assertHierarchyReason(t, caches.CanManageRole(mod, discord.Role{ID: 40, GuildID: testGuildID, Position: 1, Managed: true}), ptr(HierarchyReasonRoleManaged))
+	assertHierarchyReason(t, caches.CanManageRole(mod, discord.Role{ID: highRoleID, GuildID: testGuildID, Position: 10}), ptr(HierarchyReasonRoleTooHigh))
+	assertHierarchyReason(t, caches.CanManageRole(owner, discord.Role{ID: testGuildID, GuildID: testGuildID}), ptr(HierarchyReasonRoleEveryone))
+
+	if reason := (HierarchyError{}).Reason; reason.String() != "unknown" {
+		t.Errorf("expected unset reason to be unknown, got %q", reason)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func assertHierarchyReason(t *testing.T, err error, expected *HierarchyReason) {
+	t.Helper()
+	if expected == nil {
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		return
+	}
+	var hierarchyErr *HierarchyError
+	if !errors.As(err, &hierarchyErr) {
+		t.Fatalf("expected *HierarchyError, got %v", err)
+	}
+	if hierarchyErr.Reason != *expected {
+		t.Errorf("expected reason %q, got %q", *expected, hierarchyErr.Reason)
+	}
+}
@@ -0,0 +1,288 @@
+package cache
+
+import (
+	"container/heap"
+	"container/list"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// MessageTombstone is a deleted discord.Message which is kept for the retention window of the MessageHistoryCache.
+type MessageTombstone struct {
+	// Message is the last known revision of the deleted message.
+	Message discord.Message
+	// Revisions are the previous revisions of the message, oldest first.
+	Revisions []discord.Message
+	// DeletedAt is the time the message was deleted.
+	DeletedAt time.Time
+}
+
+// MessageHistoryCache keeps previous revisions of edited messages and tombstones of deleted messages.
+type MessageHistoryCache interface {
+	// MessageRevisions returns the previous revisions of the message, oldest first.
+	MessageRevisions(channelID snowflake.ID, messageID snowflake.ID) []discord.Message
+	// AddMessageRevision stores the given message as previous revision. The oldest revision is dropped if the limit is reached.
+	AddMessageRevision(message discord.Message)
+
+	// MessageTombstone returns the tombstone of a deleted message and a bool whether it was found or not.
+	MessageTombstone(channelID snowflake.ID, messageID snowflake.ID) (MessageTombstone, bool)
+	// MessageTombstones returns all non expired tombstones of deleted messages in the given channel.
+	MessageTombstones(channelID snowflake.ID) iter.Seq[MessageTombstone]
+	// AddMessageTombstone turns the given message and its revisions into a tombstone and returns it.
+	AddMessageTombstone(message discord.Message, deletedAt time.Time) MessageTombstone
+
+	// RemoveMessageHistoryByChannelID removes all revisions and tombstones of the given channel.
+	RemoveMessageHistoryByChannelID(channelID snowflake.ID)
+	// RemoveMessageHistoryByGuildID removes all revisions and tombstones of the given guild.
+	RemoveMessageHistoryByGuildID(guildID snowflake.ID)
+}
+
+// NewMessageHistoryCache returns a new MessageHistoryCache which keeps up to maxRevisions previous revisions of up to maxMessages messages
+// and tombstones of deleted messages for the retention duration.
+// Once maxMessages is reached, the revisions of the message which was edited the longest time ago are dropped.
+// A maxRevisions or maxMessages of 0 disables revisions and a retention of 0 disables tombstones.
+func NewMessageHistoryCache(maxRevisions int, maxMessages int, retention time.Duration) MessageHistoryCache {
+	return &messageHistoryCacheImpl{
+		maxRevisions: maxRevisions,
+		maxMessages:  maxMessages,
+		retention:    retention,
+		revisions:    make(map[snowflake.ID]map[snowflake.ID]*list.Element),
+		revisionsLRU: list.New(),
+		tombstones:   make(map[snowflake.ID]map[snowflake.ID]MessageTombstone),
+	}
+}
+
+type messageHistoryCacheImpl struct {
+	mu           sync.Mutex
+	maxRevisions int
+	maxMessages  int
+	retention    time.Duration
+	revisions    map[snowflake.ID]map[snowflake.ID]*list.Element
+	revisionsLRU *list.List
+	tombstones   map[snowflake.ID]map[snowflake.ID]MessageTombstone
+	expiries     tombstoneExpiries
+}
+
+// messageRevisions are the revisions of a message in the revisionsLRU of the messageHistoryCacheImpl.
+// The front of the list is the most recently edited message.
+type messageRevisions struct {
+	channelID snowflake.ID
+	messageID snowflake.ID
+	revisions []discord.Message
+}
+
+// tombstoneExpiry is the deletion time of a tombstone in the tombstoneExpiries.
+type tombstoneExpiry struct {
+	channelID snowflake.ID
+	messageID snowflake.ID
+	deletedAt time.Time
+}
+
+// tombstoneExpiries is a min-heap of tombstones ordered by their deletion time.
+// Entries of removed or replaced tombstones are skipped once they expire.
+type tombstoneExpiries []tombstoneExpiry
+
+func (e tombstoneExpiries) Len() int           { return len(e) }
+func (e tombstoneExpiries) Less(i, j int) bool { return e[i].deletedAt.Before(e[j].deletedAt) }
+func (e tombstoneExpiries) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e *tombstoneExpiries) Push(x any)        { *e = append(*e, x.(tombstoneExpiry)) }
+func (e *tombstoneExpiries) Pop() any {
+	old := *e
+	n := len(old)
+	x := old[n-1]
+	*e = old[:n-1]
+	return x
+}
+
+func (c *messageHistoryCacheImpl) MessageRevisions(channelID snowflake.ID, messageID snowflake.ID) []discord.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.revisions[channelID][messageID]
+	if !ok {
+		return nil
+	}
+	return append([]discord.Message(nil), element.Value.(*messageRevisions).revisions...)
+}
+
+func (c *messageHistoryCacheImpl) AddMessageRevision(message discord.Message) {
+	if c.maxRevisions <= 0 || c.maxMessages <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channelRevisions, ok := c.revisions[message.ChannelID]
+	if !ok {
+		channelRevisions = make(map[snowflake.ID]*list.Element)
+		c.revisions[message.ChannelID] = channelRevisions
+	}
+
+	element, ok := channelRevisions[message.ID]
+	if !ok {
+		element = c.revisionsLRU.PushFront(&messageRevisions{
+			channelID: message.ChannelID,
+			messageID: message.ID,
+		})
+		channelRevisions[message.ID] = element
+	} else {
+		c.revisionsLRU.MoveToFront(element)
+	}
+
+	entry := element.Value.(*messageRevisions)
+	entry.revisions = append(entry.revisions, message)
+	if len(entry.revisions) > c.maxRevisions {
+		entry.revisions = append([]discord.Message(nil), entry.revisions[len(entry.revisions)-c.maxRevisions:]...)
+	}
+
+	for c.revisionsLRU.Len() > c.maxMessages {
+		oldest := c.revisionsLRU.Back().Value.(*messageRevisions)
+		c.removeRevisions(oldest.channelID, oldest.messageID)
+	}
+}
+
+func (c *messageHistoryCacheImpl) MessageTombstone(channelID snowflake.ID, messageID snowflake.ID) (MessageTombstone, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tombstone, ok := c.tombstones[channelID][messageID]
+	if !ok || c.expired(tombstone, time.Now()) {
+		return MessageTombstone{}, false
+	}
+	return tombstone, true
+}
+
+func (c *messageHistoryCacheImpl) MessageTombstones(channelID snowflake.ID) iter.Seq[MessageTombstone] {
+	return func(yield func(MessageTombstone) bool) {
+		now := time.Now()
+		c.mu.Lock()
+		tombstones := make([]MessageTombstone, 0, len(c.tombstones[channelID]))
+		for _, tombstone := range c.tombstones[channelID] {
+			if !c.expired(tombstone, now) {
+				tombstones = append(tombstones, tombstone)
+			}
+		}
+		c.mu.Unlock()
+
+		for _, tombstone := range tombstones {
+			if !yield(tombstone) {
+				return
+			}
+		}
+	}
+}
+
+func (c *messageHistoryCacheImpl) AddMessageTombstone(message discord.Message, deletedAt time.Time) MessageTombstone {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tombstone := MessageTombstone{
+		Message:   message,
+		Revisions: c.removeRevisions(message.ChannelID, message.ID),
+		DeletedAt: deletedAt,
+	}
+
+	if c.retention <= 0 {
+		return tombstone
+	}
+	c.removeExpiredTombstones(time.Now())
+	if c.expired(tombstone, time.Now()) {
+		return tombstone
+	}
+
+	channelTombstones, ok := c.tombstones[message.ChannelID]
+	if !ok {
+		channelTombstones = make(map[snowflake.ID]MessageTombstone)
+		c.tombstones[message.ChannelID] = channelTombstones
+	}
+	channelTombstones[message.ID] = tombstone
+	heap.Push(&c.expiries, tombstoneExpiry{
+		channelID: message.ChannelID,
+		messageID: message.ID,
+		deletedAt: deletedAt,
+	})
+	return tombstone
+}
+
+func (c *messageHistoryCacheImpl) RemoveMessageHistoryByChannelID(channelID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for messageID := range c.revisions[channelID] {
+		c.removeRevisions(channelID, messageID)
+	}
+	delete(c.tombstones, channelID)
+}
+
+func (c *messageHistoryCacheImpl) RemoveMessageHistoryByGuildID(guildID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inGuild := func(message discord.Message) bool {
+		return message.GuildID != nil && *message.GuildID == guildID
+	}
+
+	for channelID, channelRevisions := range c.revisions {
+		for messageID, element := range channelRevisions {
+			if revisions := element.Value.(*messageRevisions).revisions; len(revisions) > 0 && inGuild(revisions[0]) {
+				c.removeRevisions(channelID, messageID)
+			}
+		}
+	}
+	for channelID, channelTombstones := range c.tombstones {
+		for messageID, tombstone := range channelTombstones {
+			if inGuild(tombstone.Message) {
+				delete(channelTombstones, messageID)
+			}
+		}
+		if len(channelTombstones) == 0 {
+			delete(c.tombstones, channelID)
+		}
+	}
+}
+
+// removeRevisions removes and returns the revisions of the given message. c.mu must be held.
+func (c *messageHistoryCacheImpl) removeRevisions(channelID snowflake.ID, messageID snowflake.ID) []discord.Message {
+	channelRevisions, ok := c.revisions[channelID]
+	if !ok {
+		return nil
+	}
+	element, ok := channelRevisions[messageID]
+	if !ok {
+		return nil
+	}
+	delete(channelRevisions, messageID)
+	if len(channelRevisions) == 0 {
+		delete(c.revisions, channelID)
+	}
+	return c.revisionsLRU.Remove(element).(*messageRevisions).revisions
+}
+
+func (c *messageHistoryCacheImpl) expired(tombstone MessageTombstone, now time.Time) bool {
+	return tombstone.DeletedAt.Before(now.Add(-c.retention))
+}
+
+// removeExpiredTombstones removes all tombstones older than the retention in the order they expire. c.mu must be held.
+func (c *messageHistoryCacheImpl) removeExpiredTombstones(now time.Time) {
+	cutoff := now.Add(-c.retention)
+	for len(c.expiries) > 0 && c.expiries[0].deletedAt.Before(cutoff) {
+		expiry := heap.Pop(&c.expiries).(tombstoneExpiry)
+		channelTombstones, ok := c.tombstones[expiry.channelID]
+		if !ok {
+			continue
+		}
+		// the tombstone might have been replaced by a newer one
+		if tombstone, ok := channelTombstones[expiry.messageID]; !ok || !tombstone.DeletedAt.Equal(expiry.deletedAt) {
+			continue
+		}
+		delete(channelTombstones, expiry.messageID)
+		if len(channelTombstones) == 0 {
+			delete(c.tombstones, expiry.channelID)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestMessageHistoryCache(t *testing.T) {
+	c := NewMessageHistoryCache(2, 2, time.Minute)
+
+	for _, content := range []string{"a", "b", "c"} {
+		c.AddMessageRevision(discord.Message{ID: 1, ChannelID: 2, Content: content})
+	}
+
+	revisions := c.MessageRevisions(2, 1)
+	if len(revisions) != 2 || revisions[0].Content != "b" || revisions[1].Content != "c" {
+		t.Fatalf("expected revisions [b c], got %+v", revisions)
+	}
+
+	tombstone := c.AddMessageTombstone(discord.Message{ID: 1, ChannelID: 2, Content: "d"}, time.Now())
+	if len(tombstone.Revisions) != 2 || tombstone.Message.Content != "d" {
+		t.Fatalf("unexpected tombstone %+v", tombstone)
+	}
+	if revisions = c.MessageRevisions(2, 1); revisions != nil {
+		t.Errorf("expected revisions to be moved to the tombstone, got %+v", revisions)
+	}
+	if _, ok := c.MessageTombstone(2, 1); !ok {
+		t.Errorf("expected tombstone to be cached")
+	}
+
+	c.AddMessageTombstone(discord.Message{ID: 3, ChannelID: 2}, time.Now().Add(-time.Hour))
+	if _, ok := c.MessageTombstone(2, 3); ok {
+		t.Errorf("expected expired tombstone to be removed")
+	}
+
+	impl := c.(*messageHistoryCacheImpl)
+	c.AddMessageRevision(discord.Message{ID: 6, ChannelID: 2})
+	c.AddMessageRevision(discord.Message{ID: 7, ChannelID: 2})
+	c.AddMessageRevision(discord.Message{ID: 6, ChannelID: 2})
+	c.AddMessageRevision(discord.Message{ID: 8, ChannelID: 2})
+	if c.MessageRevisions(2, 7) != nil || c.MessageRevisions(2, 6) == nil || c.MessageRevisions(2, 8) == nil {
+		t.Errorf("expected the revisions of the least recently edited message to be evicted")
+	}
+	c.RemoveMessageHistoryByChannelID(2)
+	if len(impl.revisions) != 0 || impl.revisionsLRU.Len() != 0 {
+		t.Errorf("expected all revisions of the channel to be removed, got %+v", impl.revisions)
+	}
+
+	c.AddMessageTombstone(discord.Message{ID: 4, ChannelID: 5}, time.Now().Add(-30*time.Second))
+	c.AddMessageTombstone(discord.Message{ID: 4, ChannelID: 5}, time.Now())
+	impl.removeExpiredTombstones(time.Now().Add(45 * time.Second))
+	if _, ok := c.MessageTombstone(5, 4); !ok {
+		t.Errorf("expected replaced tombstone to not expire with the old deletion time")
+	}
+	impl.removeExpiredTombstones(time.Now().Add(2 * time.Minute))
+	if len(impl.tombstones) != 0 || len(impl.expiries) != 0 {
+		t.Errorf("expected all tombstones to expire, got %+v", impl.tombstones)
+	}
+}
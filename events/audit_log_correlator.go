@@ -0,0 +1,278 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ bot.EventListener = (*AuditLogCorrelator)(nil)
+
+// NewAuditLogCorrelator returns a new AuditLogCorrelator with the AuditLogCorrelatorConfigOpt(s) applied.
+// It has to be added to the bot.Client via bot.WithEventListeners or bot.Client.AddEventListeners.
+func NewAuditLogCorrelator(opts ...AuditLogCorrelatorConfigOpt) *AuditLogCorrelator {
+	cfg := defaultAuditLogCorrelatorConfig()
+	cfg.apply(opts)
+
+	return &AuditLogCorrelator{
+		config:  cfg,
+		pending: make(map[auditLogKey][]*pendingAuditedEvent),
+		entries: make(map[auditLogKey][]receivedAuditLogEntry),
+	}
+}
+
+// AuditLogCorrelator is an opt-in bot.EventListener which matches moderation events with the discord.AuditLogEntry of the action
+// by action type, target and time window and dispatches enriched events carrying the executor and reason:
+//   - GuildMemberLeave as GuildMemberKick
+//   - GuildBan as AuditedGuildBan
+//   - GuildMemberUpdate which removed roles as AuditedGuildMemberRoleRemove
+//   - GuildChannelDelete as AuditedGuildChannelDelete
+//   - RoleDelete as AuditedRoleDelete
+//
+// Entries are received via GuildAuditLogEntryCreate which requires the gateway.IntentGuildModeration.
+// With WithAuditLogCorrelatorRESTFallback the audit log is fetched via rest.Guilds.GetAuditLog if no entry was received within the window.
+// Both require the discord.PermissionViewAuditLog. Events without a matching entry are not enriched,
+// which means a GuildMemberLeave without a kick entry was a normal leave.
+// The original events are dispatched as usual. The enriched events are dispatched from their own goroutine,
+// so the AuditLogCorrelator never blocks on the queue of the event worker it is running on.
+type AuditLogCorrelator struct {
+	config auditLogCorrelatorConfig
+
+	mu      sync.Mutex
+	pending map[auditLogKey][]*pendingAuditedEvent
+	entries map[auditLogKey][]receivedAuditLogEntry
+}
+
+type auditLogKey struct {
+	guildID    snowflake.ID
+	actionType discord.AuditLogEvent
+	targetID   snowflake.ID
+}
+
+type pendingAuditedEvent struct {
+	key          auditLogKey
+	genericEvent *GenericEvent
+	receivedAt   time.Time
+	timer        *time.Timer
+	match        func(entry discord.AuditLogEntry) bool
+	build        func(generic *GenericAuditedEvent) bot.Event
+}
+
+type receivedAuditLogEntry struct {
+	entry      discord.AuditLogEntry
+	receivedAt time.Time
+}
+
+func (c *AuditLogCorrelator) OnEvent(event bot.Event) {
+	switch e := event.(type) {
+	case *GuildAuditLogEntryCreate:
+		c.addEntry(e)
+
+	case *GuildMemberLeave:
+		c.addPending(e.GenericEvent, auditLogKey{guildID: e.GuildID, actionType: discord.AuditLogEventMemberKick, targetID: e.User.ID}, nil, func(generic *GenericAuditedEvent) bot.Event {
+			return &GuildMemberKick{GenericAuditedEvent: generic, User: e.User, Member: e.Member}
+		})
+
+	case *GuildBan:
+		c.addPending(e.GenericEvent, auditLogKey{guildID: e.GuildID, actionType: discord.AuditLogEventMemberBanAdd, targetID: e.User.ID}, nil, func(generic *GenericAuditedEvent) bot.Event {
+			return &AuditedGuildBan{GenericAuditedEvent: generic, User: e.User}
+		})
+
+	case *GuildMemberUpdate:
+		// without the old member we can't know if roles were removed
+		if e.OldMember.User.ID == 0 {
+			return
+		}
+		var removed []snowflake.ID
+		for _, roleID := range e.OldMember.RoleIDs {
+			if !slices.Contains(e.Member.RoleIDs, roleID) {
+				removed = append(removed, roleID)
+			}
+		}
+		if len(removed) == 0 {
+			return
+		}
+		// role update entries are also created for added roles, so the entry has to remove one of the roles
+		match := func(entry discord.AuditLogEntry) bool {
+			return removesRoles(entry, removed)
+		}
+		c.addPending(e.GenericEvent, auditLogKey{guildID: e.GuildID, actionType: discord.AuditLogEventMemberRoleUpdate, targetID: e.Member.User.ID}, match, func(generic *GenericAuditedEvent) bot.Event {
+			return &AuditedGuildMemberRoleRemove{GenericAuditedEvent: generic, Member: e.Member, OldMember: e.OldMember, RoleIDs: removed}
+		})
+
+	case *GuildChannelDelete:
+		c.addPending(e.GenericEvent, auditLogKey{guildID: e.GuildID, actionType: discord.AuditLogEventChannelDelete, targetID: e.ChannelID}, nil, func(generic *GenericAuditedEvent) bot.Event {
+			return &AuditedGuildChannelDelete{GenericAuditedEvent: generic, ChannelID: e.ChannelID, Channel: e.Channel}
+		})
+
+	case *RoleDelete:
+		c.addPending(e.GenericEvent, auditLogKey{guildID: e.GuildID, actionType: discord.AuditLogEventRoleDelete, targetID: e.RoleID}, nil, func(generic *GenericAuditedEvent) bot.Event {
+			return &AuditedRoleDelete{GenericAuditedEvent: generic, RoleID: e.RoleID, Role: e.Role}
+		})
+	}
+}
+
+func (c *AuditLogCorrelator) addEntry(e *GuildAuditLogEntryCreate) {
+	entry := e.AuditLogEntry
+	if entry.TargetID == nil {
+		return
+	}
+	switch entry.ActionType {
+	case discord.AuditLogEventMemberKick, discord.AuditLogEventMemberBanAdd, discord.AuditLogEventMemberRoleUpdate, discord.AuditLogEventChannelDelete, discord.AuditLogEventRoleDelete:
+	default:
+		return
+	}
+	key := auditLogKey{guildID: e.GuildID, actionType: entry.ActionType, targetID: *entry.TargetID}
+
+	c.mu.Lock()
+	if i := slices.IndexFunc(c.pending[key], func(p *pendingAuditedEvent) bool {
+		return c.matches(p, entry)
+	}); i >= 0 {
+		p := c.pending[key][i]
+		c.removePending(p)
+		c.mu.Unlock()
+		p.timer.Stop()
+		go c.dispatch(p, entry)
+		return
+	}
+
+	now := time.Now()
+	c.removeExpiredEntries(now)
+	c.entries[key] = append(c.entries[key], receivedAuditLogEntry{entry: entry, receivedAt: now})
+	c.mu.Unlock()
+}
+
+func (c *AuditLogCorrelator) addPending(genericEvent *GenericEvent, key auditLogKey, match func(entry discord.AuditLogEntry) bool, build func(generic *GenericAuditedEvent) bot.Event) {
+	p := &pendingAuditedEvent{
+		key:          key,
+		genericEvent: genericEvent,
+		receivedAt:   time.Now(),
+		match:        match,
+		build:        build,
+	}
+
+	c.mu.Lock()
+	c.removeExpiredEntries(p.receivedAt)
+	entries := c.entries[key]
+	if i := slices.IndexFunc(entries, func(entry receivedAuditLogEntry) bool {
+		return c.matches(p, entry.entry)
+	}); i >= 0 {
+		entry := entries[i].entry
+		c.entries[key] = slices.Delete(entries, i, i+1)
+		if len(c.entries[key]) == 0 {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		go c.dispatch(p, entry)
+		return
+	}
+
+	c.pending[key] = append(c.pending[key], p)
+	p.timer = time.AfterFunc(c.config.Window, func() {
+		c.expirePending(p)
+	})
+	c.mu.Unlock()
+}
+
+// expirePending is called when no entry was received for the pending event within the window and falls back to the REST API if enabled.
+func (c *AuditLogCorrelator) expirePending(p *pendingAuditedEvent) {
+	c.mu.Lock()
+	ok := c.removePending(p)
+	c.mu.Unlock()
+	if !ok || !c.config.RESTFallback {
+		return
+	}
+
+	client := p.genericEvent.Client()
+	if member, ok := client.Caches.SelfMember(p.key.guildID); ok && !client.Caches.MemberPermissions(member).Has(discord.PermissionViewAuditLog) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.RequestTimeout)
+	defer cancel()
+	auditLog, err := client.Rest.GetAuditLog(p.key.guildID, 0, p.key.actionType, 0, 0, 10, rest.WithCtx(ctx))
+	if err != nil {
+		c.config.Logger.Debug("failed to fetch audit log", slog.Any("guild_id", p.key.guildID), slog.Any("err", err))
+		return
+	}
+	for _, entry := range auditLog.AuditLogEntries {
+		if entry.TargetID != nil && *entry.TargetID == p.key.targetID && c.matches(p, entry) {
+			c.dispatch(p, entry)
+			return
+		}
+	}
+}
+
+func (c *AuditLogCorrelator) dispatch(p *pendingAuditedEvent, entry discord.AuditLogEntry) {
+	p.genericEvent.Client().EventManager.DispatchEvent(p.build(&GenericAuditedEvent{
+		GenericEvent:  p.genericEvent,
+		GuildID:       p.key.guildID,
+		AuditLogEntry: entry,
+	}))
+}
+
+// matches returns whether the entry belongs to the pending event.
+func (c *AuditLogCorrelator) matches(p *pendingAuditedEvent, entry discord.AuditLogEntry) bool {
+	return c.inWindow(entry, p.receivedAt) && (p.match == nil || p.match(entry))
+}
+
+// inWindow returns whether the entry was created within the window around the given time. The entry ID carries its creation time.
+func (c *AuditLogCorrelator) inWindow(entry discord.AuditLogEntry, t time.Time) bool {
+	diff := entry.ID.Time().Sub(t)
+	return diff >= -c.config.Window && diff <= c.config.Window
+}
+
+// removePending removes the pending event and returns whether it was still pending. c.mu must be held.
+func (c *AuditLogCorrelator) removePending(p *pendingAuditedEvent) bool {
+	pending := c.pending[p.key]
+	i := slices.Index(pending, p)
+	if i < 0 {
+		return false
+	}
+	if pending = slices.Delete(pending, i, i+1); len(pending) == 0 {
+		delete(c.pending, p.key)
+	} else {
+		c.pending[p.key] = pending
+	}
+	return true
+}
+
+// removeExpiredEntries removes all entries which were received before the window. c.mu must be held.
+func (c *AuditLogCorrelator) removeExpiredEntries(now time.Time) {
+	cutoff := now.Add(-c.config.Window)
+	for key, entries := range c.entries {
+		entries = slices.DeleteFunc(entries, func(entry receivedAuditLogEntry) bool {
+			return entry.receivedAt.Before(cutoff)
+		})
+		if len(entries) == 0 {
+			delete(c.entries, key)
+		} else {
+			c.entries[key] = entries
+		}
+	}
+}
+
+// removesRoles returns whether the discord.AuditLogChangeKeyRoleRemove change of the entry contains any of the roles.
+func removesRoles(entry discord.AuditLogEntry, roleIDs []snowflake.ID) bool {
+	for _, change := range entry.Changes {
+		if change.Key != discord.AuditLogChangeKeyRoleRemove {
+			continue
+		}
+		var roles []discord.PartialRole
+		if err := change.UnmarshalNewValue(&roles); err != nil {
+			return false
+		}
+		return slices.ContainsFunc(roles, func(role discord.PartialRole) bool {
+			return slices.Contains(roleIDs, role.ID)
+		})
+	}
+	return false
+}
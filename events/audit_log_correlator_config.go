@@ -0,0 +1,62 @@
+package events
+
+import (
+	"log/slog"
+	"time"
+)
+
+func defaultAuditLogCorrelatorConfig() auditLogCorrelatorConfig {
+	return auditLogCorrelatorConfig{
+		Logger:         slog.Default(),
+		Window:         5 * time.Second,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+type auditLogCorrelatorConfig struct {
+	Logger         *slog.Logger
+	Window         time.Duration
+	RESTFallback   bool
+	RequestTimeout time.Duration
+}
+
+// AuditLogCorrelatorConfigOpt is a functional option for configuring an AuditLogCorrelator.
+type AuditLogCorrelatorConfigOpt func(config *auditLogCorrelatorConfig)
+
+func (c *auditLogCorrelatorConfig) apply(opts []AuditLogCorrelatorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "events_audit_log_correlator"))
+}
+
+// WithAuditLogCorrelatorLogger overrides the default Logger in the auditLogCorrelatorConfig.
+func WithAuditLogCorrelatorLogger(logger *slog.Logger) AuditLogCorrelatorConfigOpt {
+	return func(config *auditLogCorrelatorConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithAuditLogCorrelatorWindow sets the time window in which an event and its discord.AuditLogEntry have to happen to be matched.
+// Events are also held back for at most this duration while waiting for their entry.
+func WithAuditLogCorrelatorWindow(window time.Duration) AuditLogCorrelatorConfigOpt {
+	return func(config *auditLogCorrelatorConfig) {
+		config.Window = window
+	}
+}
+
+// WithAuditLogCorrelatorRESTFallback sets whether the audit log should be fetched via rest.Guilds.GetAuditLog
+// for events which did not receive their discord.AuditLogEntry via the gateway within the window.
+// This is disabled by default as it makes a request for every unmatched event, e.g. every normal leave.
+func WithAuditLogCorrelatorRESTFallback(enabled bool) AuditLogCorrelatorConfigOpt {
+	return func(config *auditLogCorrelatorConfig) {
+		config.RESTFallback = enabled
+	}
+}
+
+// WithAuditLogCorrelatorRequestTimeout sets the timeout of the rest.Guilds.GetAuditLog request of the REST fallback. Defaults to 10 seconds.
+func WithAuditLogCorrelatorRequestTimeout(timeout time.Duration) AuditLogCorrelatorConfigOpt {
+	return func(config *auditLogCorrelatorConfig) {
+		config.RequestTimeout = timeout
+	}
+}
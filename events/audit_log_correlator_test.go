@@ -0,0 +1,225 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ rest.Client = (*testRestClient)(nil)
+
+// testRestClient responds to REST requests with the JSON encoded response of the doFunc.
+type testRestClient struct {
+	doFunc func(endpoint *rest.CompiledEndpoint) (any, error)
+}
+
+func (c *testRestClient) HTTPClient() *http.Client      { return http.DefaultClient }
+func (c *testRestClient) RateLimiter() rest.RateLimiter { return nil }
+func (c *testRestClient) Close(_ context.Context)       {}
+func (c *testRestClient) Do(endpoint *rest.CompiledEndpoint, _ any, rsBody any, _ ...rest.RequestOpt) error {
+	response, err := c.doFunc(endpoint)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, rsBody)
+}
+
+const (
+	testGuildID  snowflake.ID = 1
+	testTargetID snowflake.ID = 2
+	testRoleID   snowflake.ID = 3
+)
+
+// newTestClient returns a bot.Client which sends all dispatched events of type E to the returned channel.
+func newTestClient[E bot.Event](opts ...bot.EventManagerConfigOpt) (*bot.Client, <-chan E) {
+	ch := make(chan E, 10)
+	client := &bot.Client{
+		Logger: slog.New(slog.DiscardHandler),
+		Caches: cache.New(cache.WithCaches(cache.FlagsAll)),
+	}
+	client.EventManager = bot.NewEventManager(client, append([]bot.EventManagerConfigOpt{
+		bot.WithEventManagerLogger(slog.New(slog.DiscardHandler)),
+		bot.WithListenerFunc(func(e E) {
+			ch <- e
+		}),
+	}, opts...)...)
+	return client, ch
+}
+
+func receive[E any](t *testing.T, ch <-chan E) E {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		var zero E
+		t.Fatalf("expected %T to be dispatched", zero)
+		return zero
+	}
+}
+
+func expectNone[E any](t *testing.T, ch <-chan E, wait time.Duration) {
+	t.Helper()
+	select {
+	case e := <-ch:
+		t.Fatalf("expected no event, got %+v", e)
+	case <-time.After(wait):
+	}
+}
+
+func auditLogEntry(actionType discord.AuditLogEvent, changes ...discord.AuditLogChange) discord.AuditLogEntry {
+	targetID := testTargetID
+	return discord.AuditLogEntry{
+		ID:         snowflake.New(time.Now()),
+		ActionType: actionType,
+		TargetID:   &targetID,
+		UserID:     4,
+		Changes:    changes,
+	}
+}
+
+func roleChange(key discord.AuditLogChangeKey, roleID snowflake.ID) discord.AuditLogChange {
+	value, _ := json.Marshal([]discord.PartialRole{{ID: roleID}})
+	return discord.AuditLogChange{Key: key, NewValue: value}
+}
+
+func TestAuditLogCorrelatorKick(t *testing.T) {
+	client, ch := newTestClient[*GuildMemberKick]()
+	correlator := NewAuditLogCorrelator(WithAuditLogCorrelatorLogger(slog.New(slog.DiscardHandler)))
+	client.EventManager.AddEventListeners(correlator)
+
+	entry := auditLogEntry(discord.AuditLogEventMemberKick)
+	leave := func() {
+		client.EventManager.DispatchEvent(&GuildMemberLeave{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID, User: discord.User{ID: testTargetID}})
+	}
+	entryCreate := func() {
+		client.EventManager.DispatchEvent(&GuildAuditLogEntryCreate{GenericGuild: &GenericGuild{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID}, AuditLogEntry: entry})
+	}
+
+	t.Run("entry before event", func(t *testing.T) {
+		entryCreate()
+		leave()
+		if e := receive(t, ch); e.ExecutorID() != entry.UserID || e.User.ID != testTargetID {
+			t.Errorf("unexpected kick: %+v", e)
+		}
+	})
+
+	t.Run("event before entry", func(t *testing.T) {
+		leave()
+		entryCreate()
+		if e := receive(t, ch); e.ExecutorID() != entry.UserID {
+			t.Errorf("unexpected kick: %+v", e)
+		}
+	})
+}
+
+func TestAuditLogCorrelatorRoleRemove(t *testing.T) {
+	client, ch := newTestClient[*AuditedGuildMemberRoleRemove]()
+	correlator := NewAuditLogCorrelator(
+		WithAuditLogCorrelatorLogger(slog.New(slog.DiscardHandler)),
+		WithAuditLogCorrelatorWindow(50*time.Millisecond),
+	)
+	client.EventManager.AddEventListeners(correlator)
+
+	update := func() {
+		client.EventManager.DispatchEvent(&GuildMemberUpdate{
+			GenericGuildMember: &GenericGuildMember{
+				GenericEvent: NewGenericEvent(client, 0, 0),
+				GuildID:      testGuildID,
+				Member:       discord.Member{User: discord.User{ID: testTargetID}},
+			},
+			OldMember: discord.Member{User: discord.User{ID: testTargetID}, RoleIDs: []snowflake.ID{testRoleID}},
+		})
+	}
+	entryCreate := func(entry discord.AuditLogEntry) {
+		client.EventManager.DispatchEvent(&GuildAuditLogEntryCreate{GenericGuild: &GenericGuild{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID}, AuditLogEntry: entry})
+	}
+
+	t.Run("role added", func(t *testing.T) {
+		update()
+		entryCreate(auditLogEntry(discord.AuditLogEventMemberRoleUpdate, roleChange(discord.AuditLogChangeKeyRoleAdd, testRoleID)))
+		expectNone(t, ch, 100*time.Millisecond)
+	})
+
+	t.Run("other role removed", func(t *testing.T) {
+		update()
+		entryCreate(auditLogEntry(discord.AuditLogEventMemberRoleUpdate, roleChange(discord.AuditLogChangeKeyRoleRemove, testRoleID+1)))
+		expectNone(t, ch, 100*time.Millisecond)
+	})
+
+	t.Run("role removed", func(t *testing.T) {
+		update()
+		entryCreate(auditLogEntry(discord.AuditLogEventMemberRoleUpdate, roleChange(discord.AuditLogChangeKeyRoleRemove, testRoleID)))
+		if e := receive(t, ch); len(e.RoleIDs) != 1 || e.RoleIDs[0] != testRoleID {
+			t.Errorf("unexpected role remove: %+v", e)
+		}
+	})
+}
+
+func TestAuditLogCorrelatorRESTFallback(t *testing.T) {
+	var entry discord.AuditLogEntry
+	requests := make(chan struct{}, 10)
+
+	newClient := func(opts ...AuditLogCorrelatorConfigOpt) (*bot.Client, <-chan *AuditedGuildBan) {
+		client, ch := newTestClient[*AuditedGuildBan]()
+		client.Rest = rest.New(&testRestClient{doFunc: func(endpoint *rest.CompiledEndpoint) (any, error) {
+			requests <- struct{}{}
+			return discord.AuditLog{AuditLogEntries: []discord.AuditLogEntry{entry}}, nil
+		}})
+		client.EventManager.AddEventListeners(NewAuditLogCorrelator(append([]AuditLogCorrelatorConfigOpt{
+			WithAuditLogCorrelatorLogger(slog.New(slog.DiscardHandler)),
+			WithAuditLogCorrelatorWindow(20 * time.Millisecond),
+		}, opts...)...))
+		return client, ch
+	}
+	ban := func(client *bot.Client) {
+		entry = auditLogEntry(discord.AuditLogEventMemberBanAdd)
+		client.EventManager.DispatchEvent(&GuildBan{GenericGuild: &GenericGuild{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID}, User: discord.User{ID: testTargetID}})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client, ch := newClient()
+		ban(client)
+		expectNone(t, ch, 100*time.Millisecond)
+		if len(requests) != 0 {
+			t.Errorf("expected no audit log request")
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		client, ch := newClient(WithAuditLogCorrelatorRESTFallback(true))
+		ban(client)
+		if e := receive(t, ch); e.ExecutorID() != entry.UserID {
+			t.Errorf("unexpected ban: %+v", e)
+		}
+		if len(requests) != 1 {
+			t.Errorf("expected 1 audit log request, got %d", len(requests))
+		}
+	})
+}
+
+func TestAuditLogCorrelatorEventWorkers(t *testing.T) {
+	// a single worker with a full queue must not deadlock when the correlator dispatches from it
+	client, ch := newTestClient[*GuildMemberKick](bot.WithEventWorkers(1, 1))
+	client.EventManager.AddEventListeners(NewAuditLogCorrelator(WithAuditLogCorrelatorLogger(slog.New(slog.DiscardHandler))))
+
+	client.EventManager.DispatchEvent(&GuildAuditLogEntryCreate{GenericGuild: &GenericGuild{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID}, AuditLogEntry: auditLogEntry(discord.AuditLogEventMemberKick)})
+	for range 3 {
+		client.EventManager.DispatchEvent(&GuildMemberLeave{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID, User: discord.User{ID: testTargetID}})
+	}
+	receive(t, ch)
+}
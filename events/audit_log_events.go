@@ -0,0 +1,60 @@
+package events
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// GenericAuditedEvent is the base of events which were enriched with their discord.AuditLogEntry by an AuditLogCorrelator
+type GenericAuditedEvent struct {
+	*GenericEvent
+	GuildID       snowflake.ID
+	AuditLogEntry discord.AuditLogEntry
+}
+
+// ExecutorID returns the ID of the discord.User who executed the action
+func (e *GenericAuditedEvent) ExecutorID() snowflake.ID {
+	return e.AuditLogEntry.UserID
+}
+
+// Reason returns the reason of the action if one was given
+func (e *GenericAuditedEvent) Reason() *string {
+	return e.AuditLogEntry.Reason
+}
+
+// GuildMemberKick indicates that a discord.Member was kicked from the discord.Guild.
+// Discord does not send this as its own event, it is derived from GuildMemberLeave and the matching discord.AuditLogEntry.
+type GuildMemberKick struct {
+	*GenericAuditedEvent
+	User   discord.User
+	Member discord.Member // the old cached member
+}
+
+// AuditedGuildBan is a GuildBan with the discord.AuditLogEntry of the ban
+type AuditedGuildBan struct {
+	*GenericAuditedEvent
+	User discord.User
+}
+
+// AuditedGuildMemberRoleRemove indicates that discord.Role(s) were removed from a discord.Member
+type AuditedGuildMemberRoleRemove struct {
+	*GenericAuditedEvent
+	Member    discord.Member
+	OldMember discord.Member
+	RoleIDs   []snowflake.ID // the removed roles
+}
+
+// AuditedGuildChannelDelete is a GuildChannelDelete with the discord.AuditLogEntry of the deletion
+type AuditedGuildChannelDelete struct {
+	*GenericAuditedEvent
+	ChannelID snowflake.ID
+	Channel   discord.GuildChannel
+}
+
+// AuditedRoleDelete is a RoleDelete with the discord.AuditLogEntry of the deletion
+type AuditedRoleDelete struct {
+	*GenericAuditedEvent
+	RoleID snowflake.ID
+	Role   discord.Role
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+)
+
+// CollectMessages collects messages created in the given channel which pass the filterFunc.
+// Collected messages which get deleted are removed again and collecting ends with bot.CollectorEndReasonDeleted when the channel or thread gets deleted.
+// See bot.Collect for more details.
+func CollectMessages(client *bot.Client, ctx context.Context, channelID snowflake.ID, filterFunc func(e *MessageCreate) bool, opts ...bot.CollectorConfigOpt) ([]*MessageCreate, bot.CollectorEndReason) {
+	opts = append([]bot.CollectorConfigOpt{
+		bot.WithCollectorDisposeFunc(func(event bot.Event, collected bot.Event) bool {
+			e, ok := event.(*MessageDelete)
+			return ok && e.MessageID == collected.(*MessageCreate).MessageID
+		}),
+		bot.WithCollectorEndFunc(func(event bot.Event) bool {
+			return channelDeleted(event, channelID)
+		}),
+	}, opts...)
+
+	return bot.Collect(client, ctx, func(e *MessageCreate) bool {
+		return e.ChannelID == channelID && (filterFunc == nil || filterFunc(e))
+	}, opts...)
+}
+
+// CollectReactions collects reactions added to the given message which pass the filterFunc.
+// Collected reactions which get removed are removed again and collecting ends with bot.CollectorEndReasonDeleted when the message gets deleted.
+// See bot.Collect for more details.
+func CollectReactions(client *bot.Client, ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, filterFunc func(e *MessageReactionAdd) bool, opts ...bot.CollectorConfigOpt) ([]*MessageReactionAdd, bot.CollectorEndReason) {
+	opts = append([]bot.CollectorConfigOpt{
+		bot.WithCollectorDisposeFunc(func(event bot.Event, collected bot.Event) bool {
+			reaction := collected.(*MessageReactionAdd)
+			switch e := event.(type) {
+			case *MessageReactionRemove:
+				return e.MessageID == messageID && e.UserID == reaction.UserID && e.Emoji.Reaction() == reaction.Emoji.Reaction()
+			case *MessageReactionRemoveEmoji:
+				return e.MessageID == messageID && e.Emoji.Reaction() == reaction.Emoji.Reaction()
+			case *MessageReactionRemoveAll:
+				return e.MessageID == messageID
+			default:
+				return false
+			}
+		}),
+		bot.WithCollectorEndFunc(func(event bot.Event) bool {
+			return messageDeleted(event, messageID) || channelDeleted(event, channelID)
+		}),
+	}, opts...)
+
+	return bot.Collect(client, ctx, func(e *MessageReactionAdd) bool {
+		return e.MessageID == messageID && (filterFunc == nil || filterFunc(e))
+	}, opts...)
+}
+
+// CollectComponents collects component interactions on the given message which pass the filterFunc.
+// The interactionHandler is called with each collected interaction as soon as it arrives, so it can be responded to within 3 seconds.
+// Collecting ends with bot.CollectorEndReasonDeleted when the message gets deleted.
+// See bot.Collect for more details.
+func CollectComponents(client *bot.Client, ctx context.Context, messageID snowflake.ID, filterFunc func(e *ComponentInteractionCreate) bool, interactionHandler func(e *ComponentInteractionCreate), opts ...bot.CollectorConfigOpt) ([]*ComponentInteractionCreate, bot.CollectorEndReason) {
+	opts = append([]bot.CollectorConfigOpt{
+		bot.WithCollectorEndFunc(func(event bot.Event) bool {
+			return messageDeleted(event, messageID)
+		}),
+		bot.WithCollectorCollectFunc(func(event bot.Event) {
+			if interactionHandler != nil {
+				interactionHandler(event.(*ComponentInteractionCreate))
+			}
+		}),
+	}, opts...)
+
+	return bot.Collect(client, ctx, func(e *ComponentInteractionCreate) bool {
+		return e.Message.ID == messageID && (filterFunc == nil || filterFunc(e))
+	}, opts...)
+}
+
+func messageDeleted(event bot.Event, messageID snowflake.ID) bool {
+	e, ok := event.(*MessageDelete)
+	return ok && e.MessageID == messageID
+}
+
+func channelDeleted(event bot.Event, channelID snowflake.ID) bool {
+	switch e := event.(type) {
+	case *GuildChannelDelete:
+		return e.ChannelID == channelID
+	case *ThreadDelete:
+		return e.ThreadID == channelID
+	default:
+		return false
+	}
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCollectComponentsCollectFunc(t *testing.T) {
+	client := &bot.Client{}
+	client.EventManager = bot.NewEventManager(client, bot.WithEventManagerLogger(slog.New(slog.DiscardHandler)))
+
+	var (
+		mu     sync.Mutex
+		called []string
+	)
+	call := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, name)
+	}
+
+	resultCh := make(chan []*ComponentInteractionCreate, 1)
+	go func() {
+		collected, _ := CollectComponents(client, context.Background(), 1, nil,
+			func(e *ComponentInteractionCreate) {
+				call("interactionHandler")
+			},
+			bot.WithCollectorLimit(1),
+			bot.WithCollectorCollectFunc(func(event bot.Event) {
+				call("collectFunc")
+			}),
+		)
+		resultCh <- collected
+	}()
+
+	event := &ComponentInteractionCreate{
+		GenericEvent:         NewGenericEvent(client, 0, 0),
+		ComponentInteraction: discord.ComponentInteraction{Message: discord.Message{ID: 1}},
+	}
+	timeout := time.After(time.Second)
+	for {
+		// the event is dispatched until the collector was added
+		client.EventManager.DispatchEvent(event)
+		select {
+		case collected := <-resultCh:
+			if len(collected) != 1 {
+				t.Errorf("expected one collected interaction, got %d", len(collected))
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if expected := []string{"interactionHandler", "collectFunc"}; !slices.Equal(called, expected) {
+				t.Errorf("expected calls %v, got %v", expected, called)
+			}
+			return
+		case <-timeout:
+			t.Fatalf("expected collecting to end")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
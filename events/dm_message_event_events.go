@@ -23,9 +23,16 @@ type DMMessageCreate struct {
 type DMMessageUpdate struct {
 	*GenericDMMessage
 	OldMessage discord.Message
+	// Revisions are the previous revisions of the message, oldest first. They end with OldMessage if it was cached
+	// and the update changed the message, updates which only resolve embeds do not add a revision.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
 
 // DMMessageDelete is called upon deleting a discord.Message in a Channel (requires gateway.IntentsDirectMessage)
 type DMMessageDelete struct {
 	*GenericDMMessage
+	// Revisions are the previous revisions of the deleted message, oldest first.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
@@ -46,3 +46,17 @@ func (e *InviteDelete) Guild() (discord.Guild, bool) {
 	}
 	return e.Client().Caches.Guild(*e.GuildID)
 }
+
+// GuildMemberJoinInvite is dispatched by the InviteTracker after a GuildMemberJoin with the invite the discord.Member most likely joined with.
+type GuildMemberJoinInvite struct {
+	*GenericEvent
+	GuildID snowflake.ID
+	Member  discord.Member
+	// Code is the code of the used invite or empty if it could not be determined.
+	Code string
+	// Vanity is true if the member joined via the vanity URL of the guild.
+	Vanity bool
+	// Inviter is the discord.User who created the invite if known.
+	Inviter    *discord.User
+	Confidence InviteConfidence
+}
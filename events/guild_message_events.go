@@ -35,9 +35,16 @@ type GuildMessageCreate struct {
 type GuildMessageUpdate struct {
 	*GenericGuildMessage
 	OldMessage discord.Message
+	// Revisions are the previous revisions of the message, oldest first. They end with OldMessage if it was cached
+	// and the update changed the message, updates which only resolve embeds do not add a revision.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
 
 // GuildMessageDelete is called upon deleting a discord.Message in a Channel
 type GuildMessageDelete struct {
 	*GenericGuildMessage
+	// Revisions are the previous revisions of the deleted message, oldest first.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
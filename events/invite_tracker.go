@@ -0,0 +1,389 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ bot.EventListener = (*InviteTracker)(nil)
+
+var errInviteTrackerMissingPermissions = errors.New("missing manage guild permission to track invites")
+
+// InviteConfidence describes how certain the InviteTracker is about the invite a discord.Member joined with.
+type InviteConfidence int
+
+const (
+	// InviteConfidenceNone means the invite could not be determined, e.g. because of missing permissions or because the guild was not seeded yet.
+	InviteConfidenceNone InviteConfidence = iota
+	// InviteConfidenceLow means multiple members joined at the same time and the uses could not be attributed exactly.
+	InviteConfidenceLow
+	// InviteConfidenceMedium means the invite was deleted after it reached its max uses.
+	InviteConfidenceMedium
+	// InviteConfidenceHigh means the uses of exactly one invite increased by one.
+	InviteConfidenceHigh
+)
+
+func (c InviteConfidence) String() string {
+	switch c {
+	case InviteConfidenceNone:
+		return "none"
+	case InviteConfidenceLow:
+		return "low"
+	case InviteConfidenceMedium:
+		return "medium"
+	case InviteConfidenceHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// NewInviteTracker returns a new InviteTracker with the InviteTrackerConfigOpt(s) applied.
+// It has to be added to the bot.Client via bot.WithEventListeners or bot.Client.AddEventListeners.
+func NewInviteTracker(opts ...InviteTrackerConfigOpt) *InviteTracker {
+	cfg := defaultInviteTrackerConfig()
+	cfg.apply(opts)
+
+	return &InviteTracker{
+		config: cfg,
+		guilds: make(map[snowflake.ID]*guildInvites),
+	}
+}
+
+// InviteTracker is an opt-in bot.EventListener which attributes joins to invites and dispatches a GuildMemberJoinInvite after each GuildMemberJoin.
+// It seeds the invite uses of each guild via rest.Invites.GetGuildInvites and rest.Guilds.GetGuildVanityURL when the guild becomes ready,
+// keeps them up to date with InviteCreate and InviteDelete and diffs them with freshly fetched uses when a member joins.
+//
+// This requires the gateway.IntentGuildInvites and gateway.IntentGuildMembers and the discord.PermissionManageGuild.
+// Guilds in which the bot lacks the permission emit GuildMemberJoinInvite with InviteConfidenceNone.
+// GuildMemberJoinInvite is dispatched asynchronously after the invites were fetched.
+//
+// Every join fetches the invites of its guild, and joins of the same guild are handled one after another.
+// During a join raid this means one rest.Invites.GetGuildInvites request per join, queued behind the rate limit of the guild.
+// If uses of multiple invites increased between two fetches, the joins can't be told apart:
+// each of them gets one of the used invites by code order with InviteConfidenceLow, which is not necessarily the invite they used.
+type InviteTracker struct {
+	config inviteTrackerConfig
+
+	mu     sync.Mutex
+	guilds map[snowflake.ID]*guildInvites
+}
+
+type guildInvites struct {
+	// joinMu serializes seeding and joins of the guild, so uses are diffed one join after another.
+	joinMu sync.Mutex
+
+	mu           sync.Mutex
+	seeded       bool
+	invites      map[string]trackedInvite
+	vanityCode   string
+	vanityUses   int
+	deleted      []deletedInvite
+	unattributed []unattributedInviteUse
+}
+
+type trackedInvite struct {
+	code    string
+	inviter *discord.User
+	uses    int
+	maxUses int
+	vanity  bool
+}
+
+type deletedInvite struct {
+	invite    trackedInvite
+	deletedAt time.Time
+}
+
+type unattributedInviteUse struct {
+	invite trackedInvite
+	at     time.Time
+}
+
+// Uses returns the tracked uses of the invites of the given guild by code. The vanity URL is included if the guild has one.
+func (t *InviteTracker) Uses(guildID snowflake.ID) map[string]int {
+	state := t.guild(guildID, false)
+	if state == nil {
+		return nil
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	uses := make(map[string]int, len(state.invites)+1)
+	for code, invite := range state.invites {
+		uses[code] = invite.uses
+	}
+	if state.vanityCode != "" {
+		uses[state.vanityCode] = state.vanityUses
+	}
+	return uses
+}
+
+func (t *InviteTracker) OnEvent(event bot.Event) {
+	switch e := event.(type) {
+	case *GuildReady:
+		go t.seed(e.Client(), e.GuildID)
+	case *GuildJoin:
+		go t.seed(e.Client(), e.GuildID)
+	case *GuildAvailable:
+		go t.seed(e.Client(), e.GuildID)
+
+	case *GuildLeave:
+		t.removeGuild(e.GuildID)
+	case *GuildUnavailable:
+		t.removeGuild(e.GuildID)
+
+	case *GuildUpdate:
+		state := t.guild(e.GuildID, false)
+		if state == nil {
+			return
+		}
+		state.mu.Lock()
+		var vanityCode string
+		if e.Guild.VanityURLCode != nil {
+			vanityCode = *e.Guild.VanityURLCode
+		}
+		changed := vanityCode != state.vanityCode
+		if changed {
+			// the uses of the old code don't apply to the new one, so they are not diffed until the new uses were fetched
+			state.vanityCode, state.vanityUses = "", 0
+		}
+		state.mu.Unlock()
+		if changed && vanityCode != "" {
+			go t.seed(e.Client(), e.GuildID)
+		}
+
+	case *InviteCreate:
+		if e.GuildID == nil {
+			return
+		}
+		state := t.guild(*e.GuildID, true)
+		state.mu.Lock()
+		if state.invites == nil {
+			state.invites = make(map[string]trackedInvite)
+		}
+		state.invites[e.Code] = trackedInvite{
+			code:    e.Code,
+			inviter: e.Inviter,
+			uses:    e.Uses,
+			maxUses: e.MaxUses,
+		}
+		state.mu.Unlock()
+
+	case *InviteDelete:
+		if e.GuildID == nil {
+			return
+		}
+		state := t.guild(*e.GuildID, false)
+		if state == nil {
+			return
+		}
+		state.mu.Lock()
+		if invite, ok := state.invites[e.Code]; ok {
+			delete(state.invites, e.Code)
+			state.deleted = append(state.deleted, deletedInvite{invite: invite, deletedAt: time.Now()})
+		}
+		state.mu.Unlock()
+
+	case *GuildMemberJoin:
+		go t.join(e)
+	}
+}
+
+func (t *InviteTracker) guild(guildID snowflake.ID, create bool) *guildInvites {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.guilds[guildID]
+	if !ok && create {
+		state = &guildInvites{}
+		t.guilds[guildID] = state
+	}
+	return state
+}
+
+func (t *InviteTracker) removeGuild(guildID snowflake.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.guilds, guildID)
+}
+
+func (t *InviteTracker) seed(client *bot.Client, guildID snowflake.ID) {
+	state := t.guild(guildID, true)
+	state.joinMu.Lock()
+	defer state.joinMu.Unlock()
+
+	invites, vanity, err := t.fetch(client, guildID)
+	if err != nil {
+		t.config.Logger.Debug("failed to seed guild invites", slog.Any("guild_id", guildID), slog.Any("err", err))
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.setInvites(invites, vanity)
+}
+
+func (t *InviteTracker) join(e *GuildMemberJoin) {
+	state := t.guild(e.GuildID, true)
+	state.joinMu.Lock()
+	defer state.joinMu.Unlock()
+
+	event := &GuildMemberJoinInvite{
+		GenericEvent: e.GenericEvent,
+		GuildID:      e.GuildID,
+		Member:       e.Member,
+	}
+	defer func() {
+		e.Client().EventManager.DispatchEvent(event)
+	}()
+
+	invites, vanity, err := t.fetch(e.Client(), e.GuildID)
+	if err != nil {
+		t.config.Logger.Debug("failed to fetch guild invites", slog.Any("guild_id", e.GuildID), slog.Any("err", err))
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !state.seeded {
+		state.setInvites(invites, vanity)
+		return
+	}
+
+	invite, confidence, ok := state.attribute(invites, vanity, time.Now(), t.config.Window)
+	state.setInvites(invites, vanity)
+	if !ok {
+		return
+	}
+	event.Code = invite.code
+	event.Vanity = invite.vanity
+	event.Inviter = invite.inviter
+	event.Confidence = confidence
+}
+
+// fetch returns the current invites and the vanity invite of the guild. The vanity invite has an empty code if the guild has none.
+func (t *InviteTracker) fetch(client *bot.Client, guildID snowflake.ID) (map[string]trackedInvite, trackedInvite, error) {
+	if member, ok := client.Caches.SelfMember(guildID); ok && !client.Caches.MemberPermissions(member).Has(discord.PermissionManageGuild) {
+		return nil, trackedInvite{}, errInviteTrackerMissingPermissions
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.RequestTimeout)
+	defer cancel()
+
+	extendedInvites, err := client.Rest.GetGuildInvites(guildID, rest.WithCtx(ctx))
+	if err != nil {
+		return nil, trackedInvite{}, err
+	}
+	invites := make(map[string]trackedInvite, len(extendedInvites))
+	for _, invite := range extendedInvites {
+		invites[invite.Code] = trackedInvite{
+			code:    invite.Code,
+			inviter: invite.Inviter,
+			uses:    invite.Uses,
+			maxUses: invite.MaxUses,
+		}
+	}
+
+	var vanity trackedInvite
+	if guild, ok := client.Caches.Guild(guildID); ok && slices.Contains(guild.Features, discord.GuildFeatureVanityURL) {
+		partialInvite, err := client.Rest.GetGuildVanityURL(guildID, rest.WithCtx(ctx))
+		if err != nil {
+			return nil, trackedInvite{}, err
+		}
+		if partialInvite.Code != nil {
+			vanity = trackedInvite{code: *partialInvite.Code, uses: partialInvite.Uses, vanity: true}
+		}
+	}
+	return invites, vanity, nil
+}
+
+// setInvites replaces the tracked invites with the fetched ones. state.mu must be held.
+func (s *guildInvites) setInvites(invites map[string]trackedInvite, vanity trackedInvite) {
+	s.seeded = true
+	s.invites = invites
+	s.vanityCode = vanity.code
+	s.vanityUses = vanity.uses
+}
+
+// attribute diffs the tracked invites with the fetched ones and returns the invite most likely used by the joined member.
+// Additional uses from simultaneous joins are kept for the following joins within the window. state.mu must be held.
+func (s *guildInvites) attribute(invites map[string]trackedInvite, vanity trackedInvite, now time.Time, window time.Duration) (trackedInvite, InviteConfidence, bool) {
+	cutoff := now.Add(-window)
+	s.deleted = slices.DeleteFunc(s.deleted, func(invite deletedInvite) bool {
+		return invite.deletedAt.Before(cutoff)
+	})
+	s.unattributed = slices.DeleteFunc(s.unattributed, func(use unattributedInviteUse) bool {
+		return use.at.Before(cutoff)
+	})
+
+	var used []trackedInvite
+	for _, code := range slices.Sorted(maps.Keys(invites)) {
+		invite := invites[code]
+		for range invite.uses - s.invites[code].uses {
+			used = append(used, invite)
+		}
+	}
+	if vanity.code != "" && vanity.code == s.vanityCode {
+		for range vanity.uses - s.vanityUses {
+			used = append(used, vanity)
+		}
+	}
+
+	// invites which reached their max uses with this join are deleted by discord, either before or after the join was received
+	var maxedOut []trackedInvite
+	for _, code := range slices.Sorted(maps.Keys(s.invites)) {
+		if invite := s.invites[code]; reachedMaxUses(invite) {
+			if _, ok := invites[code]; !ok {
+				maxedOut = append(maxedOut, invite)
+			}
+		}
+	}
+	s.deleted = slices.DeleteFunc(s.deleted, func(invite deletedInvite) bool {
+		if reachedMaxUses(invite.invite) {
+			maxedOut = append(maxedOut, invite.invite)
+			return true
+		}
+		return false
+	})
+
+	candidates := append(used, maxedOut...)
+	switch {
+	case len(candidates) == 0:
+		if len(s.unattributed) == 0 {
+			return trackedInvite{}, InviteConfidenceNone, false
+		}
+		use := s.unattributed[0]
+		s.unattributed = s.unattributed[1:]
+		return use.invite, InviteConfidenceLow, true
+
+	case len(candidates) == 1 && len(used) == 1:
+		return candidates[0], InviteConfidenceHigh, true
+
+	case len(candidates) == 1:
+		return candidates[0], InviteConfidenceMedium, true
+
+	default:
+		for _, invite := range candidates[1:] {
+			s.unattributed = append(s.unattributed, unattributedInviteUse{invite: invite, at: now})
+		}
+		return candidates[0], InviteConfidenceLow, true
+	}
+}
+
+// reachedMaxUses returns whether one more use reaches the max uses of the invite.
+func reachedMaxUses(invite trackedInvite) bool {
+	return invite.maxUses > 0 && invite.uses+1 >= invite.maxUses
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"log/slog"
+	"time"
+)
+
+func defaultInviteTrackerConfig() inviteTrackerConfig {
+	return inviteTrackerConfig{
+		Logger:         slog.Default(),
+		Window:         10 * time.Second,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+type inviteTrackerConfig struct {
+	Logger         *slog.Logger
+	Window         time.Duration
+	RequestTimeout time.Duration
+}
+
+// InviteTrackerConfigOpt is a functional option for configuring an InviteTracker.
+type InviteTrackerConfigOpt func(config *inviteTrackerConfig)
+
+func (c *inviteTrackerConfig) apply(opts []InviteTrackerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "events_invite_tracker"))
+}
+
+// WithInviteTrackerLogger overrides the default Logger in the inviteTrackerConfig.
+func WithInviteTrackerLogger(logger *slog.Logger) InviteTrackerConfigOpt {
+	return func(config *inviteTrackerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithInviteTrackerWindow sets for how long deleted invites and unattributed uses from simultaneous joins are considered for following joins.
+func WithInviteTrackerWindow(window time.Duration) InviteTrackerConfigOpt {
+	return func(config *inviteTrackerConfig) {
+		config.Window = window
+	}
+}
+
+// WithInviteTrackerRequestTimeout sets the timeout for fetching the invites of a guild. Defaults to 10 seconds.
+func WithInviteTrackerRequestTimeout(timeout time.Duration) InviteTrackerConfigOpt {
+	return func(config *inviteTrackerConfig) {
+		config.RequestTimeout = timeout
+	}
+}
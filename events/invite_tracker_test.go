@@ -0,0 +1,163 @@
+package events
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestGuildInvitesAttribute(t *testing.T) {
+	const window = 10 * time.Second
+	now := time.Now()
+	vanity := func(code string, uses int) trackedInvite {
+		return trackedInvite{code: code, uses: uses, vanity: true}
+	}
+
+	tests := []struct {
+		name       string
+		state      *guildInvites
+		invites    map[string]trackedInvite
+		vanity     trackedInvite
+		code       string
+		confidence InviteConfidence
+	}{
+		{
+			name:       "unchanged",
+			state:      &guildInvites{invites: map[string]trackedInvite{"a": {code: "a", uses: 1}}},
+			invites:    map[string]trackedInvite{"a": {code: "a", uses: 1}},
+			confidence: InviteConfidenceNone,
+		},
+		{
+			name:       "single use",
+			state:      &guildInvites{invites: map[string]trackedInvite{"a": {code: "a", uses: 1}, "b": {code: "b"}}},
+			invites:    map[string]trackedInvite{"a": {code: "a", uses: 1}, "b": {code: "b", uses: 1}},
+			code:       "b",
+			confidence: InviteConfidenceHigh,
+		},
+		{
+			name:       "new invite",
+			state:      &guildInvites{invites: map[string]trackedInvite{}},
+			invites:    map[string]trackedInvite{"a": {code: "a", uses: 1}},
+			code:       "a",
+			confidence: InviteConfidenceHigh,
+		},
+		{
+			name:       "reached max uses",
+			state:      &guildInvites{invites: map[string]trackedInvite{"a": {code: "a", uses: 1, maxUses: 2}}},
+			invites:    map[string]trackedInvite{},
+			code:       "a",
+			confidence: InviteConfidenceMedium,
+		},
+		{
+			name: "reached max uses and deleted before",
+			state: &guildInvites{
+				invites: map[string]trackedInvite{},
+				deleted: []deletedInvite{{invite: trackedInvite{code: "a", uses: 1, maxUses: 2}, deletedAt: now}},
+			},
+			invites:    map[string]trackedInvite{},
+			code:       "a",
+			confidence: InviteConfidenceMedium,
+		},
+		{
+			name: "deleted before the window",
+			state: &guildInvites{
+				invites: map[string]trackedInvite{},
+				deleted: []deletedInvite{{invite: trackedInvite{code: "a", uses: 1, maxUses: 2}, deletedAt: now.Add(-2 * window)}},
+			},
+			invites:    map[string]trackedInvite{},
+			confidence: InviteConfidenceNone,
+		},
+		{
+			name:       "simultaneous joins",
+			state:      &guildInvites{invites: map[string]trackedInvite{"a": {code: "a"}, "b": {code: "b"}}},
+			invites:    map[string]trackedInvite{"a": {code: "a", uses: 1}, "b": {code: "b", uses: 1}},
+			code:       "a",
+			confidence: InviteConfidenceLow,
+		},
+		{
+			name: "unattributed use of a simultaneous join",
+			state: &guildInvites{
+				invites:      map[string]trackedInvite{"a": {code: "a", uses: 1}},
+				unattributed: []unattributedInviteUse{{invite: trackedInvite{code: "b"}, at: now}},
+			},
+			invites:    map[string]trackedInvite{"a": {code: "a", uses: 1}},
+			code:       "b",
+			confidence: InviteConfidenceLow,
+		},
+		{
+			name:       "vanity use",
+			state:      &guildInvites{invites: map[string]trackedInvite{}, vanityCode: "vanity", vanityUses: 5},
+			invites:    map[string]trackedInvite{},
+			vanity:     vanity("vanity", 6),
+			code:       "vanity",
+			confidence: InviteConfidenceHigh,
+		},
+		{
+			name:       "vanity code changed",
+			state:      &guildInvites{invites: map[string]trackedInvite{}, vanityCode: "old", vanityUses: 5},
+			invites:    map[string]trackedInvite{},
+			vanity:     vanity("new", 6),
+			confidence: InviteConfidenceNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite, confidence, ok := tt.state.attribute(tt.invites, tt.vanity, now, window)
+			if confidence != tt.confidence || ok != (tt.confidence != InviteConfidenceNone) {
+				t.Errorf("expected confidence %s, got %s", tt.confidence, confidence)
+			}
+			if invite.code != tt.code {
+				t.Errorf("expected invite %q, got %q", tt.code, invite.code)
+			}
+		})
+	}
+}
+
+func TestInviteTrackerVanityChange(t *testing.T) {
+	client, _ := newTestClient[*GuildMemberJoinInvite]()
+	client.Caches.AddGuild(discord.Guild{ID: testGuildID, Features: []discord.GuildFeature{discord.GuildFeatureVanityURL}})
+	vanityCode := "old"
+	vanityUses := 5
+	client.Rest = rest.New(&testRestClient{doFunc: func(endpoint *rest.CompiledEndpoint) (any, error) {
+		switch endpoint.Endpoint {
+		case rest.GetGuildInvites:
+			return []discord.ExtendedInvite{}, nil
+		case rest.GetGuildVanityURL:
+			return discord.PartialInvite{Code: &vanityCode, Uses: vanityUses}, nil
+		}
+		return nil, fmt.Errorf("unexpected request: %s", endpoint.URL)
+	}})
+
+	tracker := NewInviteTracker(WithInviteTrackerLogger(slog.New(slog.DiscardHandler)))
+	tracker.seed(client, testGuildID)
+	if uses := tracker.Uses(testGuildID); !maps.Equal(uses, map[string]int{"old": 5}) {
+		t.Fatalf("unexpected seeded uses: %v", uses)
+	}
+
+	vanityCode, vanityUses = "new", 1
+	tracker.OnEvent(&GuildUpdate{
+		GenericGuild: &GenericGuild{GenericEvent: NewGenericEvent(client, 0, 0), GuildID: testGuildID},
+		Guild:        discord.Guild{ID: testGuildID, VanityURLCode: &vanityCode},
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		uses := tracker.Uses(testGuildID)
+		if _, ok := uses["old"]; ok {
+			t.Fatalf("expected the uses of the old vanity code to be removed, got %v", uses)
+		}
+		if maps.Equal(uses, map[string]int{"new": 1}) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the uses of the new vanity code to be fetched, got %v", uses)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
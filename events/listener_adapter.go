@@ -99,9 +99,17 @@ type ListenerAdapter struct {
 	OnGuildUnban               func(event *GuildUnban)
 	OnGuildAuditLogEntryCreate func(event *GuildAuditLogEntryCreate)
 
+	// Audited Events
+	OnGuildMemberKick              func(event *GuildMemberKick)
+	OnAuditedGuildBan              func(event *AuditedGuildBan)
+	OnAuditedGuildMemberRoleRemove func(event *AuditedGuildMemberRoleRemove)
+	OnAuditedGuildChannelDelete    func(event *AuditedGuildChannelDelete)
+	OnAuditedRoleDelete            func(event *AuditedRoleDelete)
+
 	// Guild Invite Events
-	OnGuildInviteCreate func(event *InviteCreate)
-	OnGuildInviteDelete func(event *InviteDelete)
+	OnGuildInviteCreate     func(event *InviteCreate)
+	OnGuildInviteDelete     func(event *InviteDelete)
+	OnGuildMemberJoinInvite func(event *GuildMemberJoinInvite)
 
 	// Guild Member Events
 	OnGuildMemberJoin   func(event *GuildMemberJoin)
@@ -457,6 +465,28 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	// Audited Events
+	case *GuildMemberKick:
+		if listener := l.OnGuildMemberKick; listener != nil {
+			listener(e)
+		}
+	case *AuditedGuildBan:
+		if listener := l.OnAuditedGuildBan; listener != nil {
+			listener(e)
+		}
+	case *AuditedGuildMemberRoleRemove:
+		if listener := l.OnAuditedGuildMemberRoleRemove; listener != nil {
+			listener(e)
+		}
+	case *AuditedGuildChannelDelete:
+		if listener := l.OnAuditedGuildChannelDelete; listener != nil {
+			listener(e)
+		}
+	case *AuditedRoleDelete:
+		if listener := l.OnAuditedRoleDelete; listener != nil {
+			listener(e)
+		}
+
 	// Guild Invite Events
 	case *InviteCreate:
 		if listener := l.OnGuildInviteCreate; listener != nil {
@@ -466,6 +496,10 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 		if listener := l.OnGuildInviteDelete; listener != nil {
 			listener(e)
 		}
+	case *GuildMemberJoinInvite:
+		if listener := l.OnGuildMemberJoinInvite; listener != nil {
+			listener(e)
+		}
 
 	// Member Events
 	case *GuildMemberJoin:
@@ -37,9 +37,16 @@ type MessageCreate struct {
 type MessageUpdate struct {
 	*GenericMessage
 	OldMessage discord.Message
+	// Revisions are the previous revisions of the message, oldest first. They end with OldMessage if it was cached
+	// and the update changed the message, updates which only resolve embeds do not add a revision.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
 
 // MessageDelete indicates that a discord.Message got deleted
 type MessageDelete struct {
 	*GenericMessage
+	// Revisions are the previous revisions of the deleted message, oldest first.
+	// This requires cache.WithMessageHistory to be set.
+	Revisions []discord.Message
 }
@@ -0,0 +1,345 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var _ bot.EventListener = (*VoiceSessionTracker)(nil)
+
+// VoiceSessionEndReason is the reason a VoiceSession ended.
+type VoiceSessionEndReason int
+
+const (
+	// VoiceSessionEndReasonLeave means the member left the voice channel.
+	VoiceSessionEndReasonLeave VoiceSessionEndReason = iota
+	// VoiceSessionEndReasonGuildUnavailable means the guild became unavailable.
+	VoiceSessionEndReasonGuildUnavailable
+	// VoiceSessionEndReasonGuildLeave means the bot left the guild.
+	VoiceSessionEndReasonGuildLeave
+	// VoiceSessionEndReasonMissed means the leave of the member was missed, e.g. during a reconnect, and the session was ended when this was noticed.
+	VoiceSessionEndReasonMissed
+	// VoiceSessionEndReasonClosed means the VoiceSessionTracker was closed.
+	VoiceSessionEndReasonClosed
+)
+
+func (r VoiceSessionEndReason) String() string {
+	switch r {
+	case VoiceSessionEndReasonLeave:
+		return "leave"
+	case VoiceSessionEndReasonGuildUnavailable:
+		return "guild unavailable"
+	case VoiceSessionEndReasonGuildLeave:
+		return "guild leave"
+	case VoiceSessionEndReasonMissed:
+		return "missed"
+	case VoiceSessionEndReasonClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// VoiceSession is a continuous period a discord.Member spent in the voice channels of a guild.
+type VoiceSession struct {
+	GuildID   snowflake.ID
+	UserID    snowflake.ID
+	StartedAt time.Time
+	// EndedAt is zero while the session is open.
+	EndedAt   time.Time
+	EndReason VoiceSessionEndReason
+	// Channels are the voice channels the member was in, in the order they were joined.
+	Channels []VoiceSessionChannel
+	// Restored is true if the session was rebuilt from the voice states of a guild after a restart or outage.
+	// StartedAt is the time it was rebuilt in this case.
+	Restored bool
+
+	MutedDuration     time.Duration
+	DeafenedDuration  time.Duration
+	StreamingDuration time.Duration
+	VideoDuration     time.Duration
+}
+
+// VoiceSessionChannel is a voice channel the member was in during a VoiceSession.
+type VoiceSessionChannel struct {
+	ChannelID snowflake.ID
+	JoinedAt  time.Time
+}
+
+// Open returns true if the session did not end yet.
+func (s VoiceSession) Open() bool {
+	return s.EndedAt.IsZero()
+}
+
+// Duration returns the duration of the session. For open sessions this is the duration until now.
+func (s VoiceSession) Duration() time.Duration {
+	if s.Open() {
+		return time.Since(s.StartedAt)
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// VoiceSessionSink receives started and ended VoiceSession(s) from the VoiceSessionTracker, e.g. to persist them.
+// The methods are called synchronously from the event listener and must be safe for concurrent use.
+type VoiceSessionSink interface {
+	// SessionStarted is called when a member joined a voice channel or an open session was restored.
+	SessionStarted(session VoiceSession)
+	// SessionEnded is called with the final session when it ended.
+	SessionEnded(session VoiceSession)
+}
+
+// NewVoiceSessionTracker returns a new VoiceSessionTracker which reports sessions to the given VoiceSessionSink. The sink can be nil.
+// It has to be added to the bot.Client via bot.WithEventListeners or bot.Client.AddEventListeners.
+func NewVoiceSessionTracker(sink VoiceSessionSink) *VoiceSessionTracker {
+	return &VoiceSessionTracker{
+		sink:   sink,
+		guilds: make(map[snowflake.ID]map[snowflake.ID]*openVoiceSession),
+	}
+}
+
+// VoiceSessionTracker is an opt-in bot.EventListener which derives VoiceSession(s) per member from GuildVoiceJoin, GuildVoiceMove,
+// GuildVoiceLeave and GuildVoiceStateUpdate. This requires the gateway.IntentGuildVoiceStates.
+//
+// Open sessions are rebuilt from the voice states of the guild on GuildReady, GuildAvailable and GuildJoin,
+// so sessions survive restarts as Restored sessions. Sessions are ended when their guild becomes unavailable or is left.
+type VoiceSessionTracker struct {
+	sink VoiceSessionSink
+
+	mu     sync.Mutex
+	guilds map[snowflake.ID]map[snowflake.ID]*openVoiceSession
+}
+
+type openVoiceSession struct {
+	session    VoiceSession
+	state      discord.VoiceState
+	stateSince time.Time
+}
+
+// update adds the time spent in the previous voice state to the durations of the session and sets the new voice state.
+func (s *openVoiceSession) update(state discord.VoiceState, now time.Time) {
+	elapsed := now.Sub(s.stateSince)
+	if s.state.SelfMute || s.state.GuildMute {
+		s.session.MutedDuration += elapsed
+	}
+	if s.state.SelfDeaf || s.state.GuildDeaf {
+		s.session.DeafenedDuration += elapsed
+	}
+	if s.state.SelfStream {
+		s.session.StreamingDuration += elapsed
+	}
+	if s.state.SelfVideo {
+		s.session.VideoDuration += elapsed
+	}
+	s.state = state
+	s.stateSince = now
+}
+
+// snapshot returns a copy of the session with the durations accounted until now.
+func (s *openVoiceSession) snapshot(now time.Time) VoiceSession {
+	c := *s
+	c.session.Channels = append([]VoiceSessionChannel(nil), s.session.Channels...)
+	c.update(s.state, now)
+	return c.session
+}
+
+// Session returns the open VoiceSession of the member in the given guild and a bool whether it was found or not.
+func (t *VoiceSessionTracker) Session(guildID snowflake.ID, userID snowflake.ID) (VoiceSession, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	open, ok := t.guilds[guildID][userID]
+	if !ok {
+		return VoiceSession{}, false
+	}
+	return open.snapshot(time.Now()), true
+}
+
+// Sessions returns all open VoiceSession(s) in the given guild.
+func (t *VoiceSessionTracker) Sessions(guildID snowflake.ID) []VoiceSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]VoiceSession, 0, len(t.guilds[guildID]))
+	for _, open := range t.guilds[guildID] {
+		sessions = append(sessions, open.snapshot(now))
+	}
+	return sessions
+}
+
+// Close ends all open sessions with VoiceSessionEndReasonClosed, e.g. before shutting down the bot.Client.
+func (t *VoiceSessionTracker) Close() {
+	t.mu.Lock()
+	now := time.Now()
+	var ended []VoiceSession
+	for guildID, sessions := range t.guilds {
+		for userID := range sessions {
+			ended = append(ended, t.end(guildID, userID, VoiceSessionEndReasonClosed, now))
+		}
+	}
+	t.mu.Unlock()
+
+	t.report(nil, ended)
+}
+
+func (t *VoiceSessionTracker) OnEvent(event bot.Event) {
+	switch e := event.(type) {
+	case *GuildReady:
+		t.restore(e.GuildID, e.Guild.VoiceStates)
+	case *GuildAvailable:
+		t.restore(e.GuildID, e.Guild.VoiceStates)
+	case *GuildJoin:
+		t.restore(e.GuildID, e.Guild.VoiceStates)
+
+	case *GuildUnavailable:
+		t.endGuild(e.GuildID, VoiceSessionEndReasonGuildUnavailable)
+	case *GuildLeave:
+		t.endGuild(e.GuildID, VoiceSessionEndReasonGuildLeave)
+
+	case *GuildVoiceJoin:
+		t.mu.Lock()
+		now := time.Now()
+		var ended []VoiceSession
+		if _, ok := t.guilds[e.VoiceState.GuildID][e.VoiceState.UserID]; ok {
+			ended = append(ended, t.end(e.VoiceState.GuildID, e.VoiceState.UserID, VoiceSessionEndReasonMissed, now))
+		}
+		started := t.start(e.VoiceState, false, now)
+		t.mu.Unlock()
+		t.report([]VoiceSession{started}, ended)
+
+	case *GuildVoiceMove:
+		t.mu.Lock()
+		now := time.Now()
+		open, ok := t.guilds[e.VoiceState.GuildID][e.VoiceState.UserID]
+		if !ok {
+			started := t.start(e.VoiceState, false, now)
+			t.mu.Unlock()
+			t.report([]VoiceSession{started}, nil)
+			return
+		}
+		// GuildVoiceMove is also dispatched for changes inside the same channel, e.g. mutes, which are handled by GuildVoiceStateUpdate
+		if e.OldVoiceState.ChannelID == nil || *e.OldVoiceState.ChannelID != *e.VoiceState.ChannelID {
+			open.update(e.VoiceState, now)
+			open.session.Channels = append(open.session.Channels, VoiceSessionChannel{ChannelID: *e.VoiceState.ChannelID, JoinedAt: now})
+		}
+		t.mu.Unlock()
+
+	case *GuildVoiceLeave:
+		t.mu.Lock()
+		if _, ok := t.guilds[e.VoiceState.GuildID][e.VoiceState.UserID]; !ok {
+			t.mu.Unlock()
+			return
+		}
+		ended := t.end(e.VoiceState.GuildID, e.VoiceState.UserID, VoiceSessionEndReasonLeave, time.Now())
+		t.mu.Unlock()
+		t.report(nil, []VoiceSession{ended})
+
+	case *GuildVoiceStateUpdate:
+		// joins, moves and leaves are handled by their own events
+		if e.OldVoiceState.ChannelID == nil || e.VoiceState.ChannelID == nil || *e.OldVoiceState.ChannelID != *e.VoiceState.ChannelID {
+			return
+		}
+		t.mu.Lock()
+		if open, ok := t.guilds[e.VoiceState.GuildID][e.VoiceState.UserID]; ok {
+			open.update(e.VoiceState, time.Now())
+		}
+		t.mu.Unlock()
+	}
+}
+
+// restore rebuilds the open sessions of a guild from its voice states. Sessions of members which are no longer connected are ended.
+func (t *VoiceSessionTracker) restore(guildID snowflake.ID, voiceStates []discord.VoiceState) {
+	t.mu.Lock()
+	now := time.Now()
+	connected := make(map[snowflake.ID]struct{}, len(voiceStates))
+	var started, ended []VoiceSession
+	for _, state := range voiceStates {
+		if state.ChannelID == nil {
+			continue
+		}
+		state.GuildID = guildID
+		connected[state.UserID] = struct{}{}
+		if open, ok := t.guilds[guildID][state.UserID]; ok {
+			if *open.state.ChannelID != *state.ChannelID {
+				open.session.Channels = append(open.session.Channels, VoiceSessionChannel{ChannelID: *state.ChannelID, JoinedAt: now})
+			}
+			open.update(state, now)
+			continue
+		}
+		started = append(started, t.start(state, true, now))
+	}
+	for userID := range t.guilds[guildID] {
+		if _, ok := connected[userID]; !ok {
+			ended = append(ended, t.end(guildID, userID, VoiceSessionEndReasonMissed, now))
+		}
+	}
+	t.mu.Unlock()
+
+	t.report(started, ended)
+}
+
+func (t *VoiceSessionTracker) endGuild(guildID snowflake.ID, reason VoiceSessionEndReason) {
+	t.mu.Lock()
+	now := time.Now()
+	var ended []VoiceSession
+	for userID := range t.guilds[guildID] {
+		ended = append(ended, t.end(guildID, userID, reason, now))
+	}
+	t.mu.Unlock()
+
+	t.report(nil, ended)
+}
+
+// start opens a new session for the voice state and returns a copy of it. t.mu must be held.
+func (t *VoiceSessionTracker) start(state discord.VoiceState, restored bool, now time.Time) VoiceSession {
+	sessions, ok := t.guilds[state.GuildID]
+	if !ok {
+		sessions = make(map[snowflake.ID]*openVoiceSession)
+		t.guilds[state.GuildID] = sessions
+	}
+	open := &openVoiceSession{
+		session: VoiceSession{
+			GuildID:   state.GuildID,
+			UserID:    state.UserID,
+			StartedAt: now,
+			Channels:  []VoiceSessionChannel{{ChannelID: *state.ChannelID, JoinedAt: now}},
+			Restored:  restored,
+		},
+		state:      state,
+		stateSince: now,
+	}
+	sessions[state.UserID] = open
+	return open.snapshot(now)
+}
+
+// end closes the open session of the member and returns it. t.mu must be held.
+func (t *VoiceSessionTracker) end(guildID snowflake.ID, userID snowflake.ID, reason VoiceSessionEndReason, now time.Time) VoiceSession {
+	sessions := t.guilds[guildID]
+	open := sessions[userID]
+	delete(sessions, userID)
+	if len(sessions) == 0 {
+		delete(t.guilds, guildID)
+	}
+
+	open.update(open.state, now)
+	open.session.EndedAt = now
+	open.session.EndReason = reason
+	return open.session
+}
+
+func (t *VoiceSessionTracker) report(started []VoiceSession, ended []VoiceSession) {
+	if t.sink == nil {
+		return
+	}
+	for _, session := range ended {
+		t.sink.SessionEnded(session)
+	}
+	for _, session := range started {
+		t.sink.SessionStarted(session)
+	}
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var _ VoiceSessionSink = (*testVoiceSessionSink)(nil)
+
+type testVoiceSessionSink struct {
+	mu      sync.Mutex
+	started []VoiceSession
+	ended   []VoiceSession
+}
+
+func (s *testVoiceSessionSink) SessionStarted(session VoiceSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = append(s.started, session)
+}
+
+func (s *testVoiceSessionSink) SessionEnded(session VoiceSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = append(s.ended, session)
+}
+
+func TestVoiceSessionTracker(t *testing.T) {
+	const (
+		channel1 snowflake.ID = 1
+		channel2 snowflake.ID = 2
+		user1    snowflake.ID = 10
+		user2    snowflake.ID = 20
+		user3    snowflake.ID = 30
+	)
+	state := func(userID snowflake.ID, channelID snowflake.ID, mute bool) discord.VoiceState {
+		return discord.VoiceState{GuildID: testGuildID, UserID: userID, ChannelID: &channelID, SelfMute: mute}
+	}
+	generic := func(state discord.VoiceState) *GenericGuildVoiceState {
+		return &GenericGuildVoiceState{GenericEvent: NewGenericEvent(nil, 0, 0), VoiceState: state}
+	}
+	join := func(state discord.VoiceState) bot.Event {
+		return &GuildVoiceJoin{GenericGuildVoiceState: generic(state)}
+	}
+	// update returns the events the gateway handler dispatches for a voice state change of a member in a voice channel
+	update := func(old discord.VoiceState, state discord.VoiceState) []bot.Event {
+		return []bot.Event{
+			&GuildVoiceStateUpdate{GenericGuildVoiceState: generic(state), OldVoiceState: old},
+			&GuildVoiceMove{GenericGuildVoiceState: generic(state), OldVoiceState: old},
+		}
+	}
+	guild := &GenericGuild{GenericEvent: NewGenericEvent(nil, 0, 0), GuildID: testGuildID}
+
+	tests := []struct {
+		name     string
+		events   []bot.Event
+		open     map[snowflake.ID][]snowflake.ID
+		restored []snowflake.ID
+		ended    map[snowflake.ID]VoiceSessionEndReason
+	}{
+		{
+			name:   "join",
+			events: []bot.Event{join(state(user1, channel1, false))},
+			open:   map[snowflake.ID][]snowflake.ID{user1: {channel1}},
+		},
+		{
+			name:   "move",
+			events: append([]bot.Event{join(state(user1, channel1, false))}, update(state(user1, channel1, false), state(user1, channel2, false))...),
+			open:   map[snowflake.ID][]snowflake.ID{user1: {channel1, channel2}},
+		},
+		{
+			name:   "mute in the same channel",
+			events: append([]bot.Event{join(state(user1, channel1, false))}, update(state(user1, channel1, false), state(user1, channel1, true))...),
+			open:   map[snowflake.ID][]snowflake.ID{user1: {channel1}},
+		},
+		{
+			name: "restore after reconnect",
+			events: []bot.Event{
+				join(state(user1, channel1, false)),
+				join(state(user2, channel1, false)),
+				&GuildReady{GenericGuild: guild, Guild: discord.GatewayGuild{VoiceStates: []discord.VoiceState{
+					state(user1, channel2, false),
+					state(user3, channel1, false),
+				}}},
+			},
+			open:     map[snowflake.ID][]snowflake.ID{user1: {channel1, channel2}, user3: {channel1}},
+			restored: []snowflake.ID{user3},
+			ended:    map[snowflake.ID]VoiceSessionEndReason{user2: VoiceSessionEndReasonMissed},
+		},
+		{
+			name: "guild unavailable",
+			events: []bot.Event{
+				join(state(user1, channel1, false)),
+				&GuildUnavailable{GenericGuild: guild},
+			},
+			open:  map[snowflake.ID][]snowflake.ID{},
+			ended: map[snowflake.ID]VoiceSessionEndReason{user1: VoiceSessionEndReasonGuildUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &testVoiceSessionSink{}
+			tracker := NewVoiceSessionTracker(sink)
+			for _, event := range tt.events {
+				tracker.OnEvent(event)
+			}
+
+			open := make(map[snowflake.ID][]snowflake.ID)
+			var restored []snowflake.ID
+			for _, session := range tracker.Sessions(testGuildID) {
+				for _, channel := range session.Channels {
+					open[session.UserID] = append(open[session.UserID], channel.ChannelID)
+				}
+				if session.Restored {
+					restored = append(restored, session.UserID)
+				}
+			}
+			if !reflect.DeepEqual(tt.open, open) {
+				t.Errorf("expected open sessions in channels %v, got %v", tt.open, open)
+			}
+			if !reflect.DeepEqual(tt.restored, restored) {
+				t.Errorf("expected restored sessions of %v, got %v", tt.restored, restored)
+			}
+
+			ended := make(map[snowflake.ID]VoiceSessionEndReason)
+			for _, session := range sink.ended {
+				ended[session.UserID] = session.EndReason
+			}
+			if len(tt.ended) == 0 {
+				tt.ended = map[snowflake.ID]VoiceSessionEndReason{}
+			}
+			if !reflect.DeepEqual(tt.ended, ended) {
+				t.Errorf("expected ended sessions %v, got %v", tt.ended, ended)
+			}
+		})
+	}
+}
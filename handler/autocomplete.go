@@ -7,6 +7,7 @@ import (
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
@@ -15,6 +16,9 @@ type AutocompleteEvent struct {
 	*events.AutocompleteInteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// Translator translates messages into the locale of the user with the locale of the guild as fallback.
+	// It is nil if no i18n.Bundle is set with Mux.Bundle.
+	Translator i18n.Translator
 }
 
 func (e *AutocompleteEvent) GetFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
@@ -0,0 +1,434 @@
+package handler
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/omit"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// commandServerFields are the fields of an application command which are assigned by Discord and not part of a discord.ApplicationCommandCreate.
+var commandServerFields = []string{"id", "application_id", "guild_id", "version", "dm_permission", "default_permission"}
+
+// commandDefaultFields are the fields of an application command which get a default value assigned by Discord when they are not set.
+var commandDefaultFields = []string{"integration_types", "contexts"}
+
+// CommandSyncPlan is the plan to sync the commands of a single scope, which is either global or a guild.
+// It is returned by PlanCommandSync and can be applied with CommandSyncPlan.Apply.
+type CommandSyncPlan struct {
+	// GuildID is the guild of the commands or nil for global commands.
+	GuildID *snowflake.ID
+	// Create are the commands which don't exist yet.
+	Create []discord.ApplicationCommandCreate
+	// Update are the commands which exist but differ.
+	Update []CommandSyncUpdate
+	// Delete are the existing commands which are not in the commands to sync anymore.
+	Delete []discord.ApplicationCommand
+	// Unchanged are the existing commands which don't differ.
+	Unchanged []discord.ApplicationCommand
+}
+
+// CommandSyncUpdate is a command which exists but differs from the command to sync.
+type CommandSyncUpdate struct {
+	Existing discord.ApplicationCommand
+	Command  discord.ApplicationCommandCreate
+	// Changes are the human-readable changes of the fields, e.g. "options[0].max_value: 7 -> 14".
+	Changes []string
+}
+
+// HasChanges returns whether the CommandSyncPlan creates, updates or deletes any commands.
+func (p CommandSyncPlan) HasChanges() bool {
+	return len(p.Create) > 0 || len(p.Update) > 0 || len(p.Delete) > 0
+}
+
+// String returns a human-readable representation of the CommandSyncPlan, e.g. for reviewing it in CI.
+func (p CommandSyncPlan) String() string {
+	var b strings.Builder
+	scope := "global commands"
+	if p.GuildID != nil {
+		scope = "guild " + p.GuildID.String() + " commands"
+	}
+	fmt.Fprintf(&b, "%s: %d to create, %d to update, %d to delete, %d unchanged\n", scope, len(p.Create), len(p.Update), len(p.Delete), len(p.Unchanged))
+	for _, command := range p.Create {
+		fmt.Fprintf(&b, "  + %s\n", formatCommandKey(command.Type(), command.CommandName()))
+	}
+	for _, update := range p.Update {
+		fmt.Fprintf(&b, "  ~ %s\n", formatCommandKey(update.Command.Type(), update.Command.CommandName()))
+		for _, change := range update.Changes {
+			fmt.Fprintf(&b, "      %s\n", change)
+		}
+	}
+	for _, command := range p.Delete {
+		fmt.Fprintf(&b, "  - %s\n", formatCommandKey(command.Type(), command.Name()))
+	}
+	return b.String()
+}
+
+// Apply deletes, updates and creates the commands of the CommandSyncPlan. It returns on the first error.
+// Changed commands are updated in place by their ID, which keeps their permissions and mentions intact.
+func (p CommandSyncPlan) Apply(client *bot.Client, opts ...rest.RequestOpt) error {
+	for _, command := range p.Delete {
+		var err error
+		if p.GuildID == nil {
+			err = client.Rest.DeleteGlobalCommand(client.ApplicationID, command.ID(), opts...)
+		} else {
+			err = client.Rest.DeleteGuildCommand(client.ApplicationID, *p.GuildID, command.ID(), opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete command %s: %w", formatCommandKey(command.Type(), command.Name()), err)
+		}
+	}
+
+	for _, update := range p.Update {
+		commandUpdate, err := toCommandUpdate(update.Command)
+		if err != nil {
+			return err
+		}
+		if p.GuildID == nil {
+			_, err = client.Rest.UpdateGlobalCommand(client.ApplicationID, update.Existing.ID(), commandUpdate, opts...)
+		} else {
+			_, err = client.Rest.UpdateGuildCommand(client.ApplicationID, *p.GuildID, update.Existing.ID(), commandUpdate, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update command %s: %w", formatCommandKey(update.Command.Type(), update.Command.CommandName()), err)
+		}
+	}
+
+	for _, command := range p.Create {
+		var err error
+		if p.GuildID == nil {
+			_, err = client.Rest.CreateGlobalCommand(client.ApplicationID, command, opts...)
+		} else {
+			_, err = client.Rest.CreateGuildCommand(client.ApplicationID, *p.GuildID, command, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create command %s: %w", formatCommandKey(command.Type(), command.CommandName()), err)
+		}
+	}
+	return nil
+}
+
+// toCommandUpdate converts the command to sync into a discord.ApplicationCommandUpdate which overwrites all fields of the existing command.
+// Unset fields are cleared, except the fields which get a default value assigned by Discord, see commandDefaultFields.
+func toCommandUpdate(command discord.ApplicationCommandCreate) (discord.ApplicationCommandUpdate, error) {
+	permissions := func(p omit.Omit[*discord.Permissions]) omit.Omit[*discord.Permissions] {
+		if p.IsZero() {
+			return omit.NewNilPtr[discord.Permissions]()
+		}
+		return p
+	}
+	nsfw := func(nsfw *bool) *bool {
+		if nsfw == nil {
+			return omit.Ptr(false)
+		}
+		return nsfw
+	}
+
+	switch c := command.(type) {
+	case discord.SlashCommandCreate:
+		options := c.Options
+		if options == nil {
+			options = []discord.ApplicationCommandOption{}
+		}
+		return discord.SlashCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			Description:              &c.Description,
+			DescriptionLocalizations: &c.DescriptionLocalizations,
+			Options:                  &options,
+			DefaultMemberPermissions: permissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         nilIfEmpty(c.IntegrationTypes),
+			Contexts:                 nilIfEmpty(c.Contexts),
+			NSFW:                     nsfw(c.NSFW),
+		}, nil
+	case discord.UserCommandCreate:
+		return discord.UserCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: permissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         nilIfEmpty(c.IntegrationTypes),
+			Contexts:                 nilIfEmpty(c.Contexts),
+			NSFW:                     nsfw(c.NSFW),
+		}, nil
+	case discord.MessageCommandCreate:
+		return discord.MessageCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: permissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         nilIfEmpty(c.IntegrationTypes),
+			Contexts:                 nilIfEmpty(c.Contexts),
+			NSFW:                     nsfw(c.NSFW),
+		}, nil
+	case discord.EntryPointCommandCreate:
+		var handler *discord.EntryPointCommandHandlerType
+		if c.Handler != 0 {
+			handler = &c.Handler
+		}
+		return discord.EntryPointCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: permissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         nilIfEmpty(c.IntegrationTypes),
+			Contexts:                 nilIfEmpty(c.Contexts),
+			NSFW:                     nsfw(c.NSFW),
+			Handler:                  handler,
+		}, nil
+	default:
+		return nil, fmt.Errorf("failed to update command %s: unsupported command type %T", formatCommandKey(command.Type(), command.CommandName()), command)
+	}
+}
+
+// nilIfEmpty returns a pointer to the slice or nil if the slice is empty, so the field is left unchanged.
+func nilIfEmpty[T any](s []T) *[]T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
+
+// PlanCommandSync fetches the existing commands for the given guilds or globally if guildIDs is empty and compares them with the given commands.
+// It returns one CommandSyncPlan per scope without changing any commands, which makes it usable as a dry-run.
+//
+// Commands are matched by their type and name and compared semantically: fields assigned by Discord, unset fields and the order of the commands are ignored.
+func PlanCommandSync(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) ([]CommandSyncPlan, error) {
+	if len(guildIDs) == 0 {
+		var rawCommands []json.RawMessage
+		if err := client.Rest.Do(rest.GetGlobalCommands.Compile(discord.QueryValues{"with_localizations": true}, client.ApplicationID), nil, &rawCommands, opts...); err != nil {
+			return nil, err
+		}
+		plan, err := planCommandSync(nil, commands, rawCommands)
+		if err != nil {
+			return nil, err
+		}
+		return []CommandSyncPlan{*plan}, nil
+	}
+
+	plans := make([]CommandSyncPlan, 0, len(guildIDs))
+	for _, guildID := range guildIDs {
+		var rawCommands []json.RawMessage
+		if err := client.Rest.Do(rest.GetGuildCommands.Compile(discord.QueryValues{"with_localizations": true}, client.ApplicationID, guildID), nil, &rawCommands, opts...); err != nil {
+			return nil, err
+		}
+		plan, err := planCommandSync(&guildID, commands, rawCommands)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, *plan)
+	}
+	return plans, nil
+}
+
+// DiffSyncCommands is like SyncCommands but only creates, updates or deletes the commands which differ, see PlanCommandSync.
+// It returns the applied CommandSyncPlan(s) and returns on the first error.
+func DiffSyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) ([]CommandSyncPlan, error) {
+	plans, err := PlanCommandSync(client, commands, guildIDs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if err = plan.Apply(client, opts...); err != nil {
+			return plans, err
+		}
+	}
+	return plans, nil
+}
+
+type commandKey struct {
+	t    discord.ApplicationCommandType
+	name string
+}
+
+type existingCommand struct {
+	command discord.ApplicationCommand
+	fields  map[string]any
+}
+
+func planCommandSync(guildID *snowflake.ID, commands []discord.ApplicationCommandCreate, rawCommands []json.RawMessage) (*CommandSyncPlan, error) {
+	existing := make(map[commandKey]existingCommand, len(rawCommands))
+	var existingKeys []commandKey
+	for _, rawCommand := range rawCommands {
+		var command discord.UnmarshalApplicationCommand
+		if err := json.Unmarshal(rawCommand, &command); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal existing command: %w", err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(rawCommand, &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal existing command: %w", err)
+		}
+		key := commandKey{t: command.Type(), name: command.Name()}
+		existing[key] = existingCommand{command: command.ApplicationCommand, fields: fields}
+		existingKeys = append(existingKeys, key)
+	}
+
+	plan := &CommandSyncPlan{GuildID: guildID}
+	seen := make(map[commandKey]struct{}, len(commands))
+	for _, command := range commands {
+		key := commandKey{t: command.Type(), name: command.CommandName()}
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("duplicate command %s", formatCommandKey(key.t, key.name))
+		}
+		seen[key] = struct{}{}
+
+		existingCmd, ok := existing[key]
+		if !ok {
+			plan.Create = append(plan.Create, command)
+			continue
+		}
+
+		changes, err := diffCommand(command, existingCmd.fields)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 {
+			plan.Unchanged = append(plan.Unchanged, existingCmd.command)
+			continue
+		}
+		plan.Update = append(plan.Update, CommandSyncUpdate{
+			Existing: existingCmd.command,
+			Command:  command,
+			Changes:  changes,
+		})
+	}
+
+	for _, key := range existingKeys {
+		if _, ok := seen[key]; !ok {
+			plan.Delete = append(plan.Delete, existing[key].command)
+		}
+	}
+	return plan, nil
+}
+
+// diffCommand returns the changes between the command to sync and the fields of the existing command.
+func diffCommand(command discord.ApplicationCommandCreate, existing map[string]any) ([]string, error) {
+	data, err := json.Marshal(command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal command %s: %w", formatCommandKey(command.Type(), command.CommandName()), err)
+	}
+	var desired map[string]any
+	if err = json.Unmarshal(data, &desired); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal command %s: %w", formatCommandKey(command.Type(), command.CommandName()), err)
+	}
+
+	existing = normalizeCommandValue(existing).(map[string]any)
+	desired = normalizeCommandValue(desired).(map[string]any)
+	for _, field := range commandServerFields {
+		delete(existing, field)
+		delete(desired, field)
+	}
+	for _, field := range commandDefaultFields {
+		if _, ok := desired[field]; !ok {
+			delete(existing, field)
+		}
+	}
+
+	var changes []string
+	diffCommandValue("", existing, desired, &changes)
+	return changes, nil
+}
+
+// normalizeCommandValue removes unset values like null, false, "", empty arrays & objects
+// and the localized fields which are only returned for a specific locale.
+func normalizeCommandValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "name_localized" || key == "description_localized" {
+				delete(v, key)
+				continue
+			}
+			value = normalizeCommandValue(value)
+			if isUnsetCommandValue(value) {
+				delete(v, key)
+				continue
+			}
+			v[key] = value
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeCommandValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isUnsetCommandValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func diffCommandValue(path string, oldValue any, newValue any, changes *[]string) {
+	switch oldV := oldValue.(type) {
+	case map[string]any:
+		if newV, ok := newValue.(map[string]any); ok {
+			keys := make([]string, 0, len(oldV)+len(newV))
+			for key := range oldV {
+				keys = append(keys, key)
+			}
+			for key := range newV {
+				if _, ok = oldV[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				keyPath := key
+				if path != "" {
+					keyPath = path + "." + key
+				}
+				diffCommandValue(keyPath, oldV[key], newV[key], changes)
+			}
+			return
+		}
+	case []any:
+		if newV, ok := newValue.([]any); ok && len(oldV) == len(newV) {
+			for i := range oldV {
+				diffCommandValue(path+"["+strconv.Itoa(i)+"]", oldV[i], newV[i], changes)
+			}
+			return
+		}
+	}
+
+	oldData, _ := json.Marshal(oldValue)
+	newData, _ := json.Marshal(newValue)
+	if string(oldData) != string(newData) {
+		*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", path, oldData, newData))
+	}
+}
+
+func formatCommandKey(t discord.ApplicationCommandType, name string) string {
+	switch t {
+	case discord.ApplicationCommandTypeSlash:
+		return "/" + name
+	case discord.ApplicationCommandTypeUser:
+		return name + " (user)"
+	case discord.ApplicationCommandTypeMessage:
+		return name + " (message)"
+	case discord.ApplicationCommandTypePrimaryEntryPoint:
+		return name + " (entry point)"
+	default:
+		return fmt.Sprintf("%s (type %d)", name, t)
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestPlanCommandSync(t *testing.T) {
+	rawCommands := []json.RawMessage{
+		[]byte(`{"id":"1","application_id":"10","version":"100","type":1,"name":"ping","description":"Ping","default_member_permissions":null,"dm_permission":true,"nsfw":false,"integration_types":[0],"contexts":null}`),
+		[]byte(`{"id":"2","application_id":"10","version":"100","type":1,"name":"ban","description":"Ban a user","options":[{"type":4,"name":"days","description":"Days","required":false,"min_value":0,"max_value":7}],"integration_types":[0]}`),
+		[]byte(`{"id":"3","application_id":"10","version":"100","type":2,"name":"Info","description":"","integration_types":[0]}`),
+	}
+
+	maxValue := 14
+	minValue := 0
+	commands := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:        "ban",
+			Description: "Ban a user",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionInt{
+					Name:        "days",
+					Description: "Days",
+					MinValue:    &minValue,
+					MaxValue:    &maxValue,
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:        "ping",
+			Description: "Ping",
+		},
+		discord.MessageCommandCreate{
+			Name: "Report",
+		},
+	}
+
+	plan, err := planCommandSync(nil, commands, rawCommands)
+	if err != nil {
+		t.Fatalf("failed to plan command sync: %v", err)
+	}
+
+	if len(plan.Create) != 1 || plan.Create[0].CommandName() != "Report" {
+		t.Errorf("expected Report to be created, got %+v", plan.Create)
+	}
+	if len(plan.Unchanged) != 1 || plan.Unchanged[0].Name() != "ping" {
+		t.Errorf("expected ping to be unchanged, got %+v", plan.Unchanged)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].Name() != "Info" {
+		t.Errorf("expected Info to be deleted, got %+v", plan.Delete)
+	}
+	if len(plan.Update) != 1 || plan.Update[0].Command.CommandName() != "ban" {
+		t.Fatalf("expected ban to be updated, got %+v", plan.Update)
+	}
+	expectedChanges := []string{"options[0].max_value: 7 -> 14"}
+	if !reflect.DeepEqual(expectedChanges, plan.Update[0].Changes) {
+		t.Errorf("expected changes %v, got %v", expectedChanges, plan.Update[0].Changes)
+	}
+
+	expected := "global commands: 1 to create, 1 to update, 1 to delete, 1 unchanged\n" +
+		"  + Report (message)\n" +
+		"  ~ /ban\n" +
+		"      options[0].max_value: 7 -> 14\n" +
+		"  - Info (user)\n"
+	if plan.String() != expected {
+		t.Errorf("expected plan %q, got %q", expected, plan.String())
+	}
+
+	restClient := &testRestClient{}
+	if err = plan.Apply(&bot.Client{Rest: rest.New(restClient), ApplicationID: 10}); err != nil {
+		t.Fatalf("failed to apply plan: %v", err)
+	}
+	expectedEndpoints := []string{
+		"DELETE " + rest.DeleteGlobalCommand.Compile(nil, 10, 3).URL,
+		"PATCH " + rest.UpdateGlobalCommand.Compile(nil, 10, 2).URL,
+		"POST " + rest.CreateGlobalCommand.Compile(nil, 10).URL,
+	}
+	var endpoints []string
+	for _, endpoint := range restClient.endpoints {
+		endpoints = append(endpoints, endpoint.Endpoint.Method+" "+endpoint.URL)
+	}
+	if !reflect.DeepEqual(expectedEndpoints, endpoints) {
+		t.Errorf("expected requests %v, got %v", expectedEndpoints, endpoints)
+	}
+	if update, ok := restClient.bodies[1].(discord.SlashCommandUpdate); !ok || *update.Name != "ban" || len(*update.Options) != 1 {
+		t.Errorf("expected ban to be updated with its options, got %+v", restClient.bodies[1])
+	}
+}
@@ -30,6 +30,7 @@
 //
 // - [Router.Command]: for application command handlers
 // - [Router.SlashCommand]: for slash command handlers
+// - [StructSlashCommand]: for slash command handlers with the options decoded into a struct, see [SlashCommandFromStruct]
 // - [Router.UserCommand]: for user command handlers
 // - [Router.MessageCommand]: for message command handlers
 // - [Router.EntryPointCommand]: for entry point command handlers
@@ -41,6 +42,9 @@
 //
 // - [Router.Modal]: for modal handlers
 //
+// - [Paginator.Register]: for the navigation handlers of a [Paginator], which responds with messages with multiple pages
+// - [Flow.Register]: for the components and modals of a [Flow], which is a multi-step form
+//
 // To register a middleware, you can use the following methods:
 // - [Router.Use]: to add a middleware to the current router
 // - [Router.With]: to create a new router with the given middlewares
@@ -49,4 +53,10 @@
 // - [Router.Group]: to create a new router and add it to the current router
 // - [Router.Route]: to create a new sub-router with the given pattern and add it to the current router
 // - [Router.Mount]: to mount the given router with the given pattern to the current router
+//
+// Text commands in messages (e.g. "!ban @user 7d spam") are handled by a [TextMux], which is created with [NewText].
+// It supports middlewares, groups, subcommands and aliases in the same way, and [TextArgs] parses the arguments.
+//
+// Handlers can be tested without a connection to Discord with the [github.com/disgoorg/disgo/handler/handlertest] package,
+// which builds interactions, routes them through a [Router] and records the responses.
 package handler
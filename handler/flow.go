@@ -0,0 +1,460 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// Reserved IDs of the components and modals of a Flow.
+const (
+	flowContinueID = "-continue"
+	flowBackID     = "-back"
+	flowCancelID   = "-cancel"
+	flowSubmitID   = "-submit"
+)
+
+// FlowAnswers are the answers of a Flow by step name and custom ID of the component.
+// Text inputs, radio groups and checkboxes have a single value, select menus and checkbox groups their selected values,
+// file uploads the URLs of the attachments and buttons no values.
+type FlowAnswers map[string]map[string][]string
+
+// Has returns whether the component of the step was answered.
+func (a FlowAnswers) Has(step string, customID string) bool {
+	_, ok := a[step][customID]
+	return ok
+}
+
+// Values returns the values of the component of the step.
+func (a FlowAnswers) Values(step string, customID string) []string {
+	return a[step][customID]
+}
+
+// Value returns the first value of the component of the step or an empty string.
+func (a FlowAnswers) Value(step string, customID string) string {
+	if values := a[step][customID]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// FlowContext is passed to the functions of a FlowStep.
+type FlowContext struct {
+	// UserID is the ID of the user who started the flow.
+	UserID snowflake.ID
+	// Answers are the answers of all steps so far. In FlowStep.Validate and FlowStep.Next they include the answers of the current step.
+	Answers        FlowAnswers
+	customIDPrefix string
+}
+
+// CustomID returns the custom ID for a component of a message step. The id must not start with "-" or contain "/".
+func (c FlowContext) CustomID(id string) string {
+	return c.customIDPrefix + id
+}
+
+// FlowStep is a single step of a Flow. At least one of Modal and Message needs to be set.
+type FlowStep struct {
+	// Name is the unique name of the step. The answers of the step are stored under this name.
+	Name string
+
+	// Modal returns the modal of the step. Its custom ID is set by the Flow.
+	// Modals can contain text inputs, select menus, radio groups, checkbox groups, checkboxes and file uploads in labels.
+	// Use FlowContext.Answers to prefill the modal when a user goes back to the step.
+	Modal func(ctx FlowContext) discord.ModalCreate
+
+	// Message returns the message of the step. Components need a custom ID from FlowContext.CustomID.
+	// The values of select menus are collected until the step is submitted by clicking a button, which is added to the answers.
+	// If the message has select menus, a continue button is added, which submits the step with the collected answers.
+	// If Modal is set too, the continue button opens the modal instead, whose answers are merged with the collected ones.
+	// Back and cancel buttons are appended in a new action row.
+	Message func(ctx FlowContext) discord.MessageCreate
+
+	// Validate validates the answers of the step. If it returns an error, the error message is shown to the user and the step is repeated.
+	Validate func(ctx FlowContext) error
+
+	// Next returns the name of the next step, which allows branching. An empty name finishes the flow.
+	// If Next is nil, the following step is used.
+	Next func(ctx FlowContext) string
+}
+
+// FlowFinishHandler handles the answers of a finished Flow. It needs to respond to the interaction.
+type FlowFinishHandler func(answers FlowAnswers, e *InteractionEvent) error
+
+// FlowStartEvent is an interaction event which can start a Flow, e.g. *CommandEvent or *ComponentEvent.
+type FlowStartEvent interface {
+	CreateMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) error
+	Modal(modalCreate discord.ModalCreate, opts ...rest.RequestOpt) error
+	User() discord.User
+}
+
+// NewFlow returns a new Flow with the given steps, which starts with the first step and calls finish with all answers after the last step.
+// It panics if there are no steps, a step has neither a modal nor a message or the step names are not unique.
+func NewFlow(name string, steps []FlowStep, finish FlowFinishHandler, opts ...FlowConfigOpt) *Flow {
+	cfg := defaultFlowConfig()
+	cfg.apply(opts)
+
+	if len(steps) == 0 {
+		panic(fmt.Sprintf("flow %q must have at least one step", name))
+	}
+	index := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if step.Modal == nil && step.Message == nil {
+			panic(fmt.Sprintf("step %q of flow %q must have a modal or a message", step.Name, name))
+		}
+		if _, ok := index[step.Name]; ok {
+			panic(fmt.Sprintf("step %q of flow %q is not unique", step.Name, name))
+		}
+		index[step.Name] = i
+	}
+
+	return &Flow{
+		config: cfg,
+		name:   name,
+		steps:  steps,
+		index:  index,
+		finish: finish,
+		state: NewState[flowState](
+			WithStateTTL(cfg.Timeout),
+			WithStateStore(cfg.Store),
+		),
+	}
+}
+
+// Flow is a multi-step form of modals and messages with components. The answers are stored per user and flow in a StateStore,
+// which resets its timeout with every step. Users can go back to the previous step or cancel the flow at any time.
+// Only the user who started the flow can use it.
+//
+//	onboarding := handler.NewFlow("onboarding", []handler.FlowStep{
+//		{Name: "profile", Modal: profileModal, Validate: validateProfile},
+//		{Name: "role", Message: roleMessage, Next: nextAfterRole},
+//		{Name: "team", Modal: teamModal},
+//	}, func(answers handler.FlowAnswers, e *handler.InteractionEvent) error { ... })
+//	onboarding.Register(r)
+//	r.SlashCommand("/onboarding", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+//		return onboarding.Start(e.Ctx, e)
+//	})
+type Flow struct {
+	config flowConfig
+	name   string
+	steps  []FlowStep
+	index  map[string]int
+	finish FlowFinishHandler
+	state  *State[flowState]
+}
+
+type flowState struct {
+	UserID  snowflake.ID `json:"user_id"`
+	Step    string       `json:"step"`
+	History []string     `json:"history,omitempty"`
+	Answers FlowAnswers  `json:"answers"`
+}
+
+// Register registers the handler of the components and modals of the Flow on the Router.
+func (f *Flow) Register(r Router) {
+	r.Interaction(f.config.Prefix+"/"+f.name+"/{state}/{step}/{id}", f.handle)
+}
+
+// Start starts the flow for the user of the interaction. If the first step has no message, its modal is opened directly.
+func (f *Flow) Start(ctx context.Context, e FlowStartEvent) error {
+	state := flowState{
+		UserID:  e.User().ID,
+		Step:    f.steps[0].Name,
+		Answers: FlowAnswers{},
+	}
+	token, err := f.state.Save(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	step := f.steps[0]
+	if step.Message == nil {
+		return e.Modal(f.modal(step, f.context(token, state)))
+	}
+	return e.CreateMessage(f.message(step, f.context(token, state), state))
+}
+
+func (f *Flow) handle(e *InteractionEvent) error {
+	token, id := e.Vars["state"], e.Vars["id"]
+	state, ok, err := f.state.Load(e.Ctx, token)
+	if err != nil {
+		return err
+	}
+	stepIndex, stepOK := f.index[state.Step]
+	if !ok || !stepOK || e.Vars["step"] != state.Step {
+		return e.CreateMessage(f.config.ExpiredMessage)
+	}
+	if e.User().ID != state.UserID {
+		return e.CreateMessage(f.config.NotAllowedMessage)
+	}
+	step := f.steps[stepIndex]
+
+	switch id {
+	case flowCancelID:
+		if err = f.state.Delete(e.Ctx, token); err != nil {
+			return err
+		}
+		return f.respond(e, f.config.CanceledMessage)
+	case flowBackID:
+		if len(state.History) > 0 {
+			state.Step = state.History[len(state.History)-1]
+			state.History = state.History[:len(state.History)-1]
+		}
+		return f.show(e, token, state)
+	case flowContinueID:
+		if step.Modal != nil {
+			return e.Modal(f.modal(step, f.context(token, state)))
+		}
+	}
+
+	// the values of the select menus are collected until the step is submitted with a button, the continue button or the modal
+	answers := f.selectMenuAnswers(step, f.context(token, state), state)
+	switch interaction := e.Interaction.(type) {
+	case discord.ComponentInteraction:
+		if id == flowContinueID {
+			break
+		}
+		answers[id] = componentValues(interaction.Data)
+		if _, ok = interaction.Data.(discord.ButtonInteractionData); !ok {
+			state.Answers[step.Name] = answers
+			if err = f.state.Update(e.Ctx, token, state); err != nil {
+				return err
+			}
+			return e.DeferUpdateMessage()
+		}
+	case discord.ModalSubmitInteraction:
+		maps.Copy(answers, modalValues(interaction.Data))
+	default:
+		return fmt.Errorf("unsupported interaction type %d for flow %q", e.Type(), f.name)
+	}
+	state.Answers[step.Name] = answers
+
+	ctx := f.context(token, state)
+	if step.Validate != nil {
+		if validateErr := step.Validate(ctx); validateErr != nil {
+			// keep the invalid answers, so the modal can be prefilled
+			if err = f.state.Update(e.Ctx, token, state); err != nil {
+				return err
+			}
+			if e.Type() == discord.InteractionTypeModalSubmit {
+				messageCreate := f.message(step, ctx, state)
+				messageCreate.Content = validateErr.Error()
+				return f.respond(e, messageCreate)
+			}
+			return e.CreateMessage(discord.MessageCreate{
+				Content: validateErr.Error(),
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+	}
+
+	var next string
+	if step.Next != nil {
+		next = step.Next(ctx)
+	} else if stepIndex+1 < len(f.steps) {
+		next = f.steps[stepIndex+1].Name
+	}
+	if next == "" {
+		if err = f.state.Delete(e.Ctx, token); err != nil {
+			return err
+		}
+		return f.finish(state.Answers, e)
+	}
+	if _, ok = f.index[next]; !ok {
+		return fmt.Errorf("flow %q has no step %q", f.name, next)
+	}
+
+	state.History = append(state.History, state.Step)
+	state.Step = next
+	return f.show(e, token, state)
+}
+
+// show stores the state and responds with the message of the current step.
+// Modal steps are shown with a continue button, as modals can't be opened in response to a modal.
+func (f *Flow) show(e *InteractionEvent, token string, state flowState) error {
+	if err := f.state.Update(e.Ctx, token, state); err != nil {
+		return err
+	}
+	step := f.steps[f.index[state.Step]]
+	return f.respond(e, f.message(step, f.context(token, state), state))
+}
+
+// respond updates the message of the flow or creates a new one if the interaction has no message,
+// e.g. when the modal of the first step was opened by a command.
+func (f *Flow) respond(e *InteractionEvent, messageCreate discord.MessageCreate) error {
+	if interaction, ok := e.Interaction.(discord.ModalSubmitInteraction); ok && interaction.Message == nil {
+		return e.CreateMessage(messageCreate)
+	}
+
+	embeds := messageCreate.Embeds
+	if embeds == nil {
+		embeds = []discord.Embed{}
+	}
+	components := messageCreate.Components
+	if components == nil {
+		components = []discord.LayoutComponent{}
+	}
+	messageUpdate := discord.MessageUpdate{
+		Embeds:     &embeds,
+		Components: &components,
+	}
+	if messageCreate.Flags.Has(discord.MessageFlagIsComponentsV2) {
+		flags := discord.MessageFlagIsComponentsV2
+		messageUpdate.Flags = &flags
+	} else {
+		messageUpdate.Content = &messageCreate.Content
+	}
+	return e.UpdateMessage(messageUpdate)
+}
+
+func (f *Flow) context(token string, state flowState) FlowContext {
+	return FlowContext{
+		UserID:         state.UserID,
+		Answers:        state.Answers,
+		customIDPrefix: f.config.Prefix + "/" + f.name + "/" + token + "/" + state.Step + "/",
+	}
+}
+
+// selectMenuAnswers returns a copy of the answers of the select menus in the message of the step.
+// Answers of buttons are dropped, as only the button which submits the step is an answer.
+func (f *Flow) selectMenuAnswers(step FlowStep, ctx FlowContext, state flowState) map[string][]string {
+	answers := make(map[string][]string)
+	if step.Message == nil {
+		return answers
+	}
+	for selectMenu := range selectMenus(step.Message(ctx).Components) {
+		id, ok := strings.CutPrefix(selectMenu.GetCustomID(), ctx.customIDPrefix)
+		if !ok {
+			continue
+		}
+		if values, ok := state.Answers[step.Name][id]; ok {
+			answers[id] = values
+		}
+	}
+	return answers
+}
+
+func (f *Flow) modal(step FlowStep, ctx FlowContext) discord.ModalCreate {
+	modalCreate := step.Modal(ctx)
+	modalCreate.CustomID = ctx.CustomID(flowSubmitID)
+	return modalCreate
+}
+
+func (f *Flow) message(step FlowStep, ctx FlowContext, state flowState) discord.MessageCreate {
+	messageCreate := f.config.ContinueMessage
+	if step.Message != nil {
+		messageCreate = step.Message(ctx)
+	}
+
+	buttons := make([]discord.InteractiveComponent, 0, 3)
+	if step.Modal != nil || hasSelectMenu(messageCreate.Components) {
+		buttons = append(buttons, discord.NewPrimaryButton(f.config.Labels[0], ctx.CustomID(flowContinueID)))
+	}
+	buttons = append(buttons,
+		discord.NewSecondaryButton(f.config.Labels[1], ctx.CustomID(flowBackID)).WithDisabled(len(state.History) == 0),
+		discord.NewDangerButton(f.config.Labels[2], ctx.CustomID(flowCancelID)),
+	)
+	messageCreate.Components = append(slices.Clip(messageCreate.Components), discord.NewActionRow(buttons...))
+	if f.config.Ephemeral {
+		messageCreate.Flags = messageCreate.Flags.Add(discord.MessageFlagEphemeral)
+	}
+	return messageCreate
+}
+
+// selectMenus returns an iter.Seq of the select menus in the components.
+func selectMenus(components []discord.LayoutComponent) iter.Seq[discord.SelectMenuComponent] {
+	return func(yield func(discord.SelectMenuComponent) bool) {
+		for _, component := range components {
+			if selectMenu, ok := component.(discord.SelectMenuComponent); ok && !yield(selectMenu) {
+				return
+			}
+			c, ok := component.(discord.ComponentIter)
+			if !ok {
+				continue
+			}
+			for sub := range c.SubComponents() {
+				if selectMenu, ok := sub.(discord.SelectMenuComponent); ok && !yield(selectMenu) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func hasSelectMenu(components []discord.LayoutComponent) bool {
+	for range selectMenus(components) {
+		return true
+	}
+	return false
+}
+
+func componentValues(data discord.ComponentInteractionData) []string {
+	switch d := data.(type) {
+	case discord.StringSelectMenuInteractionData:
+		return d.Values
+	case discord.UserSelectMenuInteractionData:
+		return snowflakeValues(d.Values)
+	case discord.RoleSelectMenuInteractionData:
+		return snowflakeValues(d.Values)
+	case discord.MentionableSelectMenuInteractionData:
+		return snowflakeValues(d.Values)
+	case discord.ChannelSelectMenuInteractionData:
+		return snowflakeValues(d.Values)
+	default:
+		return nil
+	}
+}
+
+func modalValues(data discord.ModalSubmitInteractionData) map[string][]string {
+	values := make(map[string][]string)
+	for component := range data.AllComponents() {
+		switch c := component.(type) {
+		case discord.TextInputComponent:
+			values[c.CustomID] = []string{c.Value}
+		case discord.StringSelectMenuComponent:
+			values[c.CustomID] = c.Values
+		case discord.UserSelectMenuComponent:
+			values[c.CustomID] = snowflakeValues(c.Values)
+		case discord.RoleSelectMenuComponent:
+			values[c.CustomID] = snowflakeValues(c.Values)
+		case discord.MentionableSelectMenuComponent:
+			values[c.CustomID] = snowflakeValues(c.Values)
+		case discord.ChannelSelectMenuComponent:
+			values[c.CustomID] = snowflakeValues(c.Values)
+		case discord.RadioGroupComponent:
+			if c.Value != nil {
+				values[c.CustomID] = []string{*c.Value}
+			} else {
+				values[c.CustomID] = nil
+			}
+		case discord.CheckboxGroupComponent:
+			values[c.CustomID] = c.Values
+		case discord.CheckboxComponent:
+			values[c.CustomID] = []string{strconv.FormatBool(c.Value)}
+		case discord.FileUploadComponent:
+			attachments := data.Attachments(c.CustomID)
+			urls := make([]string, 0, len(attachments))
+			for _, attachment := range attachments {
+				urls = append(urls, attachment.URL)
+			}
+			values[c.CustomID] = urls
+		}
+	}
+	return values
+}
+
+func snowflakeValues(ids []snowflake.ID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	return values
+}
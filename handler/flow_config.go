@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultFlowConfig() flowConfig {
+	return flowConfig{
+		Prefix:    "/flow",
+		Timeout:   15 * time.Minute,
+		Ephemeral: true,
+		Labels:    [3]string{"Continue", "Back", "Cancel"},
+		ContinueMessage: discord.MessageCreate{
+			Content: "Continue with the next step.",
+		},
+		CanceledMessage: discord.MessageCreate{
+			Content: "Canceled.",
+		},
+		NotAllowedMessage: discord.MessageCreate{
+			Content: "You can't use this form.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+		ExpiredMessage: discord.MessageCreate{
+			Content: "This form has expired. Please run the command again.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+	}
+}
+
+type flowConfig struct {
+	Prefix            string
+	Timeout           time.Duration
+	Store             StateStore
+	Ephemeral         bool
+	Labels            [3]string
+	ContinueMessage   discord.MessageCreate
+	CanceledMessage   discord.MessageCreate
+	NotAllowedMessage discord.MessageCreate
+	ExpiredMessage    discord.MessageCreate
+}
+
+// FlowConfigOpt is a functional option for configuring a Flow.
+type FlowConfigOpt func(config *flowConfig)
+
+func (c *flowConfig) apply(opts []FlowConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithFlowPrefix sets the custom ID prefix of the components and modals of the flow. Defaults to "/flow".
+// The custom IDs are built as prefix + "/" + name + "/" + token + "/" + step + "/" + id.
+func WithFlowPrefix(prefix string) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.Prefix = prefix
+	}
+}
+
+// WithFlowTimeout sets after which time without interaction the flow expires. Defaults to 15 minutes.
+func WithFlowTimeout(timeout time.Duration) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WithFlowStateStore overrides the in-memory StateStore which stores the answers of the flow.
+func WithFlowStateStore(store StateStore) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.Store = store
+	}
+}
+
+// WithFlowEphemeral sets whether the messages of the flow are ephemeral. Defaults to true.
+func WithFlowEphemeral(ephemeral bool) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.Ephemeral = ephemeral
+	}
+}
+
+// WithFlowButtonLabels overrides the labels of the continue, back and cancel buttons.
+func WithFlowButtonLabels(continueLabel string, back string, cancel string) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.Labels = [3]string{continueLabel, back, cancel}
+	}
+}
+
+// WithFlowContinueMessage overrides the message with the continue button, which is shown before modal steps without FlowStep.Message.
+func WithFlowContinueMessage(message discord.MessageCreate) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.ContinueMessage = message
+	}
+}
+
+// WithFlowCanceledMessage overrides the message which replaces the flow when it is canceled.
+func WithFlowCanceledMessage(message discord.MessageCreate) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.CanceledMessage = message
+	}
+}
+
+// WithFlowNotAllowedMessage overrides the message which is sent when another user than the one who started the flow uses it.
+func WithFlowNotAllowedMessage(message discord.MessageCreate) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.NotAllowedMessage = message
+	}
+}
+
+// WithFlowExpiredMessage overrides the message which is sent when the flow expired or the step is no longer active.
+func WithFlowExpiredMessage(message discord.MessageCreate) FlowConfigOpt {
+	return func(config *flowConfig) {
+		config.ExpiredMessage = message
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestFlow(t *testing.T) {
+	var finished FlowAnswers
+	flow := NewFlow("test", []FlowStep{
+		{
+			Name: "role",
+			Message: func(ctx FlowContext) discord.MessageCreate {
+				return discord.MessageCreate{
+					Content: "role",
+					Components: []discord.LayoutComponent{discord.NewActionRow(
+						discord.NewPrimaryButton("Developer", ctx.CustomID("developer")),
+						discord.NewPrimaryButton("Designer", ctx.CustomID("designer")),
+					)},
+				}
+			},
+			Validate: func(ctx FlowContext) error {
+				if ctx.Answers.Has("role", "designer") {
+					return errors.New("no designers")
+				}
+				return nil
+			},
+		},
+		{
+			Name: "confirm",
+			Message: func(ctx FlowContext) discord.MessageCreate {
+				return discord.MessageCreate{
+					Content: "confirm",
+					Components: []discord.LayoutComponent{discord.NewActionRow(
+						discord.NewPrimaryButton("Confirm", ctx.CustomID("confirm")),
+					)},
+				}
+			},
+		},
+	}, func(answers FlowAnswers, e *InteractionEvent) error {
+		finished = answers
+		return e.UpdateMessage(discord.MessageUpdate{})
+	})
+
+	mux := New()
+	flow.Register(mux)
+	mux.Component("/foo", func(e *ComponentEvent) error {
+		return flow.Start(e.Ctx, e)
+	})
+
+	buttons := func(components []discord.LayoutComponent, row int) []discord.InteractiveComponent {
+		return components[row].(discord.ActionRowComponent).Components
+	}
+
+	response := clickButton(t, mux, "/foo", testUserID)
+	messageCreate, ok := response.Data.(discord.MessageCreate)
+	if !ok || messageCreate.Content != "role" || !messageCreate.Flags.Has(discord.MessageFlagEphemeral) {
+		t.Fatalf("expected role step, got %+v", response)
+	}
+	developer := buttons(messageCreate.Components, 0)[0].(discord.ButtonComponent).CustomID
+	designer := buttons(messageCreate.Components, 0)[1].(discord.ButtonComponent).CustomID
+
+	if response = clickButton(t, mux, developer, "1"); !reflect.DeepEqual(response.Data, flow.config.NotAllowedMessage) {
+		t.Errorf("expected not allowed message, got %+v", response)
+	}
+	if response = clickButton(t, mux, designer, testUserID); response.Data.(discord.MessageCreate).Content != "no designers" {
+		t.Errorf("expected validation error, got %+v", response)
+	}
+
+	response = clickButton(t, mux, developer, testUserID)
+	messageUpdate, ok := response.Data.(discord.MessageUpdate)
+	if !ok || *messageUpdate.Content != "confirm" {
+		t.Fatalf("expected confirm step, got %+v", response)
+	}
+	if response = clickButton(t, mux, developer, testUserID); !reflect.DeepEqual(response.Data, flow.config.ExpiredMessage) {
+		t.Errorf("expected expired message for previous step, got %+v", response)
+	}
+
+	back := buttons(*messageUpdate.Components, 1)[0].(discord.ButtonComponent).CustomID
+	if response = clickButton(t, mux, back, testUserID); *response.Data.(discord.MessageUpdate).Content != "role" {
+		t.Fatalf("expected role step after back, got %+v", response)
+	}
+	response = clickButton(t, mux, developer, testUserID)
+	confirm := buttons(*response.Data.(discord.MessageUpdate).Components, 0)[0].(discord.ButtonComponent).CustomID
+	clickButton(t, mux, confirm, testUserID)
+
+	expected := FlowAnswers{
+		"role":    {"developer": nil},
+		"confirm": {"confirm": nil},
+	}
+	if !reflect.DeepEqual(expected, finished) {
+		t.Errorf("expected answers %+v, got %+v", expected, finished)
+	}
+}
+
+func TestFlowSelectMenus(t *testing.T) {
+	var finished FlowAnswers
+	flow := NewFlow("test", []FlowStep{
+		{
+			Name: "class",
+			Message: func(ctx FlowContext) discord.MessageCreate {
+				return discord.MessageCreate{
+					Content: "class",
+					Components: []discord.LayoutComponent{
+						discord.NewActionRow(discord.NewStringSelectMenu(ctx.CustomID("main"), "Main")),
+						discord.NewActionRow(discord.NewStringSelectMenu(ctx.CustomID("alt"), "Alt")),
+					},
+				}
+			},
+		},
+	}, func(answers FlowAnswers, e *InteractionEvent) error {
+		finished = answers
+		return e.UpdateMessage(discord.MessageUpdate{})
+	})
+
+	mux := New()
+	flow.Register(mux)
+	mux.Component("/foo", func(e *ComponentEvent) error {
+		return flow.Start(e.Ctx, e)
+	})
+
+	response := clickButton(t, mux, "/foo", testUserID)
+	components := response.Data.(discord.MessageCreate).Components
+	mainID := components[0].(discord.ActionRowComponent).Components[0].(discord.StringSelectMenuComponent).CustomID
+	altID := components[1].(discord.ActionRowComponent).Components[0].(discord.StringSelectMenuComponent).CustomID
+	continueID := components[2].(discord.ActionRowComponent).Components[0].(discord.ButtonComponent).CustomID
+
+	for _, customID := range []string{mainID, altID} {
+		response = dispatch(t, mux, testInteraction(t, "testdata/component/select_menu_component.json", customID, testUserID))
+		if response.Type != discord.InteractionResponseTypeDeferredUpdateMessage {
+			t.Fatalf("expected select menu to be collected without advancing, got %+v", response)
+		}
+	}
+	clickButton(t, mux, continueID, testUserID)
+
+	expected := FlowAnswers{
+		"class": {
+			"main": {"mage", "rogue"},
+			"alt":  {"mage", "rogue"},
+		},
+	}
+	if !reflect.DeepEqual(expected, finished) {
+		t.Errorf("expected answers %+v, got %+v", expected, finished)
+	}
+}
+
+func TestFlowModal(t *testing.T) {
+	const attachmentID snowflake.ID = 10
+
+	var finished FlowAnswers
+	flow := NewFlow("test", []FlowStep{
+		{
+			Name: "team",
+			Modal: func(ctx FlowContext) discord.ModalCreate {
+				return discord.ModalCreate{Title: "team"}
+			},
+		},
+	}, func(answers FlowAnswers, e *InteractionEvent) error {
+		finished = answers
+		return e.CreateMessage(discord.MessageCreate{Content: "done"})
+	})
+
+	mux := New()
+	flow.Register(mux)
+	mux.Component("/foo", func(e *ComponentEvent) error {
+		return flow.Start(e.Ctx, e)
+	})
+
+	response := clickButton(t, mux, "/foo", testUserID)
+	modalCreate, ok := response.Data.(discord.ModalCreate)
+	if !ok {
+		t.Fatalf("expected modal of the first step, got %+v", response)
+	}
+
+	color := "blue"
+	var interaction map[string]any
+	if err := json.Unmarshal(testInteraction(t, "testdata/component/button_component.json", modalCreate.CustomID, testUserID), &interaction); err != nil {
+		t.Fatalf("failed to unmarshal interaction: %v", err)
+	}
+	delete(interaction, "message")
+	interaction["type"] = discord.InteractionTypeModalSubmit
+	interaction["data"] = map[string]any{
+		"custom_id": modalCreate.CustomID,
+		"components": []discord.LayoutComponent{
+			discord.NewLabel("Name", discord.NewShortTextInput("name").WithValue("disgo")),
+			discord.NewLabel("Color", discord.RadioGroupComponent{CustomID: "color", Value: &color}),
+			discord.NewLabel("Roles", discord.CheckboxGroupComponent{CustomID: "roles", Values: []string{"lead", "dev"}}),
+			discord.NewLabel("Logo", discord.FileUploadComponent{CustomID: "logo", Values: []snowflake.ID{attachmentID}}),
+		},
+		"resolved": map[string]any{
+			"attachments": map[snowflake.ID]discord.Attachment{
+				attachmentID: {ID: attachmentID, URL: "https://cdn.discordapp.com/logo.png"},
+			},
+		},
+	}
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		t.Fatalf("failed to marshal interaction: %v", err)
+	}
+
+	if response = dispatch(t, mux, data); response.Data.(discord.MessageCreate).Content != "done" {
+		t.Fatalf("expected flow to finish, got %+v", response)
+	}
+	expected := FlowAnswers{
+		"team": {
+			"name":  {"disgo"},
+			"color": {"blue"},
+			"roles": {"lead", "dev"},
+			"logo":  {"https://cdn.discordapp.com/logo.png"},
+		},
+	}
+	if !reflect.DeepEqual(expected, finished) {
+		t.Errorf("expected answers %+v, got %+v", expected, finished)
+	}
+}
@@ -31,6 +31,7 @@ import (
 )
 
 // SyncCommands sets the given commands for the given guilds or globally if guildIDs is empty. It will return on the first error for multiple guilds.
+// Use DiffSyncCommands to only change the commands which differ.
 func SyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) error {
 	if len(guildIDs) == 0 {
 		_, err := client.Rest.SetGlobalCommands(client.ApplicationID, commands, opts...)
@@ -74,6 +75,10 @@ func (h *handlerHolder[T]) Match(path string, t discord.InteractionType, t2 int)
 	return true
 }
 
+func (h *handlerHolder[T]) routePattern() string {
+	return h.pattern
+}
+
 func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 	parseVariables(path, h.pattern, event.Vars)
 
@@ -88,8 +93,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                       event.Respond,
 				ResponseState:                 event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case SlashCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -100,8 +106,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                       event.Respond,
 				ResponseState:                 event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case UserCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -112,8 +119,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                       event.Respond,
 				ResponseState:                 event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case MessageCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -124,8 +132,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                       event.Respond,
 				ResponseState:                 event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case EntryPointCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -136,8 +145,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                       event.Respond,
 				ResponseState:                 event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case AutocompleteHandler:
 		return handler(&AutocompleteEvent{
@@ -147,8 +157,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                 event.Respond,
 				ResponseState:           event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case ComponentHandler:
 		return handler(&ComponentEvent{
@@ -158,8 +169,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:              event.Respond,
 				ResponseState:        event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case ButtonComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -170,8 +182,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:              event.Respond,
 				ResponseState:        event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case SelectMenuComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -182,8 +195,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:              event.Respond,
 				ResponseState:        event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	case ModalHandler:
 		return handler(&ModalEvent{
@@ -193,8 +207,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				Respond:                event.Respond,
 				ResponseState:          event.ResponseState,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:       event.Vars,
+			Ctx:        event.Ctx,
+			Translator: event.Translator,
 		})
 	}
 	return errors.New("unknown handler type")
@@ -0,0 +1,20 @@
+// Package handlertest provides utilities for testing interaction handlers of a [handler.Router].
+//
+// The New* functions build realistic interactions, like Discord would send them, which are routed through a
+// [handler.Router] with [Do]. The returned [Recorder] captures the interaction response, followup messages and
+// updates of the original response, so tests can assert on the content, components, ephemeral flags and the error
+// returned by the handler. REST requests are handled by a fake REST client and never reach Discord.
+//
+//	r := handler.New()
+//	r.SlashCommand("/ping", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+//		return e.CreateMessage(discord.MessageCreate{Content: "pong", Flags: discord.MessageFlagEphemeral})
+//	})
+//
+//	rec := handlertest.Do(r, handlertest.NewSlashCommand("/ping", nil))
+//	if rec.Err() != nil {
+//		t.Fatal(rec.Err())
+//	}
+//	if message, _ := rec.Message(); message.Content != "pong" || !rec.Ephemeral() {
+//		t.Errorf("unexpected response: %+v", rec.Response())
+//	}
+package handlertest
@@ -0,0 +1,139 @@
+package handlertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+func TestRecorder(t *testing.T) {
+	target := discord.User{ID: 123, Username: "target"}
+	role := discord.Role{ID: 456, Name: "admin"}
+	errFailed := errors.New("failed")
+
+	r := handler.New()
+	r.SlashCommand("/settings/roles/add", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if data.User("user").ID != target.ID || data.Role("role").Name != role.Name || data.Int("days") != 3 {
+			t.Errorf("unexpected options: %+v", data.Options)
+		}
+		return e.CreateMessage(discord.MessageCreate{Content: "added", Flags: discord.MessageFlagEphemeral})
+	})
+	r.SlashCommand("/defer", func(_ discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if err := e.DeferCreateMessage(false); err != nil {
+			return err
+		}
+		if _, err := e.UpdateInteractionResponse(discord.NewMessageUpdate().WithContent("done")); err != nil {
+			return err
+		}
+		_, err := e.CreateFollowupMessage(discord.MessageCreate{Content: "followup"})
+		return err
+	})
+	r.SlashCommand("/twice", func(_ discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if err := e.CreateMessage(discord.MessageCreate{Content: "first"}); err != nil {
+			return err
+		}
+		return e.CreateMessage(discord.MessageCreate{Content: "second"})
+	})
+	r.SlashCommand("/fail", func(_ discord.SlashCommandInteractionData, _ *handler.CommandEvent) error {
+		return errFailed
+	})
+	r.UserCommand("/avatar", func(data discord.UserCommandInteractionData, e *handler.CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: data.TargetUser().Username})
+	})
+	r.MessageCommand("/quote", func(data discord.MessageCommandInteractionData, e *handler.CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: data.TargetMessage().Content})
+	})
+	r.Autocomplete("/search", func(e *handler.AutocompleteEvent) error {
+		return e.AutocompleteResult([]discord.AutocompleteChoice{
+			discord.AutocompleteChoiceString{Name: e.Data.String("query"), Value: e.Data.String("query")},
+		})
+	})
+	r.ButtonComponent("/delete/{id}", func(_ discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+		return e.UpdateMessage(discord.NewMessageUpdate().WithContent("deleted " + e.Vars["id"]))
+	})
+	r.SelectMenuComponent("/color", func(data discord.SelectMenuInteractionData, e *handler.ComponentEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: data.(discord.StringSelectMenuInteractionData).Values[0]})
+	})
+	r.Modal("/feedback", func(e *handler.ModalEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: e.Data.Text("text")})
+	})
+	r.Component("/open", func(e *handler.ComponentEvent) error {
+		return e.Modal(discord.ModalCreate{CustomID: "/feedback", Title: "Feedback"})
+	})
+
+	t.Run("slash command", func(t *testing.T) {
+		rec := Do(r, NewSlashCommand("/settings/roles/add", []Option{
+			UserOption("user", target),
+			RoleOption("role", role),
+			IntOption("days", 3),
+		}))
+		if rec.Err() != nil {
+			t.Fatalf("unexpected error: %v", rec.Err())
+		}
+		if message, ok := rec.Message(); !ok || message.Content != "added" || !rec.Ephemeral() {
+			t.Errorf("unexpected response: %+v", rec.Response())
+		}
+	})
+
+	t.Run("defer", func(t *testing.T) {
+		rec := Do(r, NewSlashCommand("/defer", nil))
+		if rec.Err() != nil {
+			t.Fatalf("unexpected error: %v", rec.Err())
+		}
+		if response := rec.Response(); response == nil || response.Type != discord.InteractionResponseTypeDeferredCreateMessage {
+			t.Errorf("unexpected response: %+v", response)
+		}
+		if updates := rec.Updates(); len(updates) != 1 || *updates[0].Content != "done" {
+			t.Errorf("unexpected updates: %+v", updates)
+		}
+		if followups := rec.Followups(); len(followups) != 1 || followups[0].Content != "followup" {
+			t.Errorf("unexpected followups: %+v", followups)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if rec := Do(r, NewSlashCommand("/twice", nil)); !errors.Is(rec.Err(), discord.ErrInteractionAlreadyReplied) {
+			t.Errorf("expected already replied error, got %v", rec.Err())
+		}
+		if rec := Do(r, NewSlashCommand("/fail", nil, WithDM())); !errors.Is(rec.Err(), errFailed) {
+			t.Errorf("expected handler error, got %v", rec.Err())
+		}
+	})
+
+	t.Run("context menu commands", func(t *testing.T) {
+		if message, _ := Do(r, NewUserCommand("avatar", target)).Message(); message.Content != "target" {
+			t.Errorf("unexpected user command response: %+v", message)
+		}
+		if message, _ := Do(r, NewMessageCommand("quote", discord.Message{ID: 789, Content: "hello"})).Message(); message.Content != "hello" {
+			t.Errorf("unexpected message command response: %+v", message)
+		}
+	})
+
+	t.Run("autocomplete", func(t *testing.T) {
+		choices, ok := Do(r, NewAutocomplete("/search", []Option{Focused(StringOption("query", "go"))})).AutocompleteChoices()
+		if !ok || len(choices) != 1 || choices[0].ChoiceName() != "go" {
+			t.Errorf("unexpected choices: %+v", choices)
+		}
+	})
+
+	t.Run("components", func(t *testing.T) {
+		if update, ok := Do(r, NewButton("/delete/42")).MessageUpdate(); !ok || *update.Content != "deleted 42" {
+			t.Errorf("unexpected button response: %+v", update)
+		}
+		if message, _ := Do(r, NewStringSelectMenu("/color", []string{"red"})).Message(); message.Content != "red" {
+			t.Errorf("unexpected select menu response: %+v", message)
+		}
+		if modal, ok := Do(r, NewButton("/open")).Modal(); !ok || modal.CustomID != "/feedback" {
+			t.Errorf("unexpected modal: %+v", modal)
+		}
+	})
+
+	t.Run("modal", func(t *testing.T) {
+		rec := Do(r, NewModalSubmit("/feedback", []discord.LayoutComponent{TextInput("text", "great")}))
+		if message, _ := rec.Message(); message.Content != "great" {
+			t.Errorf("unexpected modal response: %+v", rec.Response())
+		}
+	})
+}
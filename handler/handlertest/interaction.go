@@ -0,0 +1,352 @@
+package handlertest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var idCounter atomic.Uint64
+
+// newID returns a new unique snowflake.ID.
+func newID() snowflake.ID {
+	return snowflake.New(time.Now()) + snowflake.ID(idCounter.Add(1))
+}
+
+// Option is an option of a slash command or autocomplete interaction.
+type Option struct {
+	name    string
+	typ     discord.ApplicationCommandOptionType
+	value   any
+	focused bool
+	resolve func(resolved *discord.ResolvedData)
+}
+
+// Focused marks the Option as the focused option of an autocomplete interaction.
+func Focused(option Option) Option {
+	option.focused = true
+	return option
+}
+
+// StringOption returns a string Option.
+func StringOption(name string, value string) Option {
+	return Option{name: name, typ: discord.ApplicationCommandOptionTypeString, value: value}
+}
+
+// IntOption returns an integer Option.
+func IntOption(name string, value int) Option {
+	return Option{name: name, typ: discord.ApplicationCommandOptionTypeInt, value: value}
+}
+
+// FloatOption returns a number Option.
+func FloatOption(name string, value float64) Option {
+	return Option{name: name, typ: discord.ApplicationCommandOptionTypeFloat, value: value}
+}
+
+// BoolOption returns a boolean Option.
+func BoolOption(name string, value bool) Option {
+	return Option{name: name, typ: discord.ApplicationCommandOptionTypeBool, value: value}
+}
+
+// UserOption returns a user Option and adds the user to the resolved data.
+func UserOption(name string, user discord.User) Option {
+	return Option{
+		name:  name,
+		typ:   discord.ApplicationCommandOptionTypeUser,
+		value: user.ID,
+		resolve: func(resolved *discord.ResolvedData) {
+			resolved.Users[user.ID] = user
+		},
+	}
+}
+
+// RoleOption returns a role Option and adds the role to the resolved data.
+func RoleOption(name string, role discord.Role) Option {
+	return Option{
+		name:  name,
+		typ:   discord.ApplicationCommandOptionTypeRole,
+		value: role.ID,
+		resolve: func(resolved *discord.ResolvedData) {
+			resolved.Roles[role.ID] = role
+		},
+	}
+}
+
+// ChannelOption returns a channel Option and adds the channel to the resolved data.
+func ChannelOption(name string, channel discord.ResolvedChannel) Option {
+	return Option{
+		name:  name,
+		typ:   discord.ApplicationCommandOptionTypeChannel,
+		value: channel.ID,
+		resolve: func(resolved *discord.ResolvedData) {
+			resolved.Channels[channel.ID] = channel
+		},
+	}
+}
+
+// AttachmentOption returns an attachment Option and adds the attachment to the resolved data.
+func AttachmentOption(name string, attachment discord.Attachment) Option {
+	return Option{
+		name:  name,
+		typ:   discord.ApplicationCommandOptionTypeAttachment,
+		value: attachment.ID,
+		resolve: func(resolved *discord.ResolvedData) {
+			resolved.Attachments[attachment.ID] = attachment
+		},
+	}
+}
+
+// NewSlashCommand returns a slash command interaction with the given options.
+// The path contains the command name and optionally the subcommand group and subcommand, e.g. "/settings/roles/add".
+func NewSlashCommand(path string, options []Option, opts ...InteractionConfigOpt) discord.ApplicationCommandInteraction {
+	return newInteraction(discord.InteractionTypeApplicationCommand, commandData(path, options), opts).(discord.ApplicationCommandInteraction)
+}
+
+// NewAutocomplete returns an autocomplete interaction with the given options. One of the options should be marked as Focused.
+// The path contains the command name and optionally the subcommand group and subcommand, e.g. "/settings/roles/add".
+func NewAutocomplete(path string, options []Option, opts ...InteractionConfigOpt) discord.AutocompleteInteraction {
+	return newInteraction(discord.InteractionTypeAutocomplete, commandData(path, options), opts).(discord.AutocompleteInteraction)
+}
+
+// NewUserCommand returns a user command interaction which targets the given user.
+func NewUserCommand(name string, target discord.User, opts ...InteractionConfigOpt) discord.ApplicationCommandInteraction {
+	data := map[string]any{
+		"id":        newID(),
+		"name":      name,
+		"type":      discord.ApplicationCommandTypeUser,
+		"target_id": target.ID,
+		"resolved": discord.UserCommandResolved{
+			Users: map[snowflake.ID]discord.User{target.ID: target},
+		},
+	}
+	return newInteraction(discord.InteractionTypeApplicationCommand, data, opts).(discord.ApplicationCommandInteraction)
+}
+
+// NewMessageCommand returns a message command interaction which targets the given message.
+func NewMessageCommand(name string, target discord.Message, opts ...InteractionConfigOpt) discord.ApplicationCommandInteraction {
+	data := map[string]any{
+		"id":        newID(),
+		"name":      name,
+		"type":      discord.ApplicationCommandTypeMessage,
+		"target_id": target.ID,
+		"resolved": discord.MessageCommandResolved{
+			Messages: map[snowflake.ID]discord.Message{target.ID: target},
+		},
+	}
+	return newInteraction(discord.InteractionTypeApplicationCommand, data, opts).(discord.ApplicationCommandInteraction)
+}
+
+// NewButton returns a button interaction with the given custom ID.
+func NewButton(customID string, opts ...InteractionConfigOpt) discord.ComponentInteraction {
+	return newComponent(discord.ComponentTypeButton, customID, nil, nil, opts)
+}
+
+// NewStringSelectMenu returns a string select menu interaction with the given selected values.
+func NewStringSelectMenu(customID string, values []string, opts ...InteractionConfigOpt) discord.ComponentInteraction {
+	return newComponent(discord.ComponentTypeStringSelectMenu, customID, values, nil, opts)
+}
+
+// NewUserSelectMenu returns a user select menu interaction with the given selected users.
+func NewUserSelectMenu(customID string, users []discord.User, opts ...InteractionConfigOpt) discord.ComponentInteraction {
+	values := make([]snowflake.ID, len(users))
+	resolved := map[snowflake.ID]discord.User{}
+	for i, user := range users {
+		values[i] = user.ID
+		resolved[user.ID] = user
+	}
+	return newComponent(discord.ComponentTypeUserSelectMenu, customID, values, map[string]any{"users": resolved}, opts)
+}
+
+// NewRoleSelectMenu returns a role select menu interaction with the given selected roles.
+func NewRoleSelectMenu(customID string, roles []discord.Role, opts ...InteractionConfigOpt) discord.ComponentInteraction {
+	values := make([]snowflake.ID, len(roles))
+	resolved := map[snowflake.ID]discord.Role{}
+	for i, role := range roles {
+		values[i] = role.ID
+		resolved[role.ID] = role
+	}
+	return newComponent(discord.ComponentTypeRoleSelectMenu, customID, values, map[string]any{"roles": resolved}, opts)
+}
+
+// NewChannelSelectMenu returns a channel select menu interaction with the given selected channels.
+func NewChannelSelectMenu(customID string, channels []discord.ResolvedChannel, opts ...InteractionConfigOpt) discord.ComponentInteraction {
+	values := make([]snowflake.ID, len(channels))
+	resolved := map[snowflake.ID]discord.ResolvedChannel{}
+	for i, channel := range channels {
+		values[i] = channel.ID
+		resolved[channel.ID] = channel
+	}
+	return newComponent(discord.ComponentTypeChannelSelectMenu, customID, values, map[string]any{"channels": resolved}, opts)
+}
+
+// NewModalSubmit returns a modal submit interaction with the given submitted components, e.g. created with TextInput.
+func NewModalSubmit(customID string, components []discord.LayoutComponent, opts ...InteractionConfigOpt) discord.ModalSubmitInteraction {
+	data := map[string]any{
+		"custom_id":  customID,
+		"components": components,
+	}
+	return newInteraction(discord.InteractionTypeModalSubmit, data, opts).(discord.ModalSubmitInteraction)
+}
+
+// TextInput returns a submitted text input with the given value for NewModalSubmit.
+func TextInput(customID string, value string) discord.LayoutComponent {
+	return discord.NewLabel(customID, discord.NewShortTextInput(customID).WithValue(value))
+}
+
+// commandData returns the data of a slash command or autocomplete interaction.
+func commandData(path string, options []Option) map[string]any {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 3 || parts[0] == "" {
+		panic(fmt.Sprintf("handlertest: invalid command path %q", path))
+	}
+
+	resolved := discord.ResolvedData{
+		Users:       map[snowflake.ID]discord.User{},
+		Members:     map[snowflake.ID]discord.ResolvedMember{},
+		Roles:       map[snowflake.ID]discord.Role{},
+		Channels:    map[snowflake.ID]discord.ResolvedChannel{},
+		Attachments: map[snowflake.ID]discord.Attachment{},
+	}
+	rawOptions := make([]map[string]any, len(options))
+	for i, option := range options {
+		rawOptions[i] = map[string]any{
+			"name":  option.name,
+			"type":  option.typ,
+			"value": option.value,
+		}
+		if option.focused {
+			rawOptions[i]["focused"] = true
+		}
+		if option.resolve != nil {
+			option.resolve(&resolved)
+		}
+	}
+
+	// nest the options into the subcommand and subcommand group
+	for i := len(parts) - 1; i > 0; i-- {
+		optionType := discord.ApplicationCommandOptionTypeSubCommand
+		if i < len(parts)-1 {
+			optionType = discord.ApplicationCommandOptionTypeSubCommandGroup
+		}
+		rawOptions = []map[string]any{{
+			"name":    parts[i],
+			"type":    optionType,
+			"options": rawOptions,
+		}}
+	}
+
+	return map[string]any{
+		"id":       newID(),
+		"name":     parts[0],
+		"type":     discord.ApplicationCommandTypeSlash,
+		"options":  rawOptions,
+		"resolved": resolved,
+	}
+}
+
+func newComponent(componentType discord.ComponentType, customID string, values any, resolved map[string]any, opts []InteractionConfigOpt) discord.ComponentInteraction {
+	data := map[string]any{
+		"component_type": componentType,
+		"custom_id":      customID,
+	}
+	if values != nil {
+		data["values"] = values
+	}
+	if resolved != nil {
+		data["resolved"] = resolved
+	}
+	return newInteraction(discord.InteractionTypeComponent, data, opts).(discord.ComponentInteraction)
+}
+
+// newInteraction builds the payload of an interaction like Discord sends it and unmarshals it.
+func newInteraction(interactionType discord.InteractionType, data map[string]any, opts []InteractionConfigOpt) discord.Interaction {
+	cfg := defaultInteractionConfig()
+	cfg.apply(opts)
+
+	id := newID()
+	payload := map[string]any{
+		"id":              id,
+		"type":            interactionType,
+		"application_id":  DefaultApplicationID,
+		"token":           "token-" + strconv.FormatUint(uint64(id), 10),
+		"version":         1,
+		"locale":          cfg.Locale,
+		"app_permissions": cfg.AppPermissions,
+		"entitlements":    cfg.Entitlements,
+		"data":            data,
+	}
+	if cfg.Entitlements == nil {
+		payload["entitlements"] = []discord.Entitlement{}
+	}
+	if cfg.GuildLocale != nil {
+		payload["guild_locale"] = *cfg.GuildLocale
+	}
+
+	channel := any(cfg.Channel)
+	if cfg.GuildID != nil {
+		now := time.Now()
+		payload["guild_id"] = *cfg.GuildID
+		payload["context"] = discord.InteractionContextTypeGuild
+		payload["member"] = discord.ResolvedMember{
+			Member: discord.Member{
+				User:     cfg.User,
+				RoleIDs:  cfg.Roles,
+				JoinedAt: &now,
+				GuildID:  *cfg.GuildID,
+			},
+			Permissions: cfg.Permissions,
+		}
+		if cfg.Channel == nil {
+			channel = map[string]any{
+				"id":          DefaultChannelID,
+				"type":        discord.ChannelTypeGuildText,
+				"guild_id":    *cfg.GuildID,
+				"name":        "general",
+				"permissions": cfg.Permissions,
+			}
+		}
+	} else {
+		payload["context"] = discord.InteractionContextTypeBotDM
+		payload["user"] = cfg.User
+		if cfg.Channel == nil {
+			channel = map[string]any{
+				"id":          DefaultChannelID,
+				"type":        discord.ChannelTypeDM,
+				"permissions": cfg.Permissions,
+			}
+		}
+	}
+	payload["channel"] = channel
+	payload["channel_id"] = DefaultChannelID
+	if cfg.Channel != nil {
+		payload["channel_id"] = cfg.Channel.ID()
+	}
+
+	switch {
+	case cfg.Message != nil:
+		payload["message"] = *cfg.Message
+	case interactionType == discord.InteractionTypeComponent:
+		payload["message"] = discord.Message{
+			ID:        newID(),
+			ChannelID: DefaultChannelID,
+			Author:    discord.User{ID: DefaultApplicationID, Username: "application", Bot: true},
+		}
+	}
+
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		panic(fmt.Sprintf("handlertest: failed to marshal interaction: %s", err))
+	}
+	interaction, err := discord.UnmarshalInteraction(buf)
+	if err != nil {
+		panic(fmt.Sprintf("handlertest: failed to unmarshal interaction: %s", err))
+	}
+	return interaction
+}
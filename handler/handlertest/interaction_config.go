@@ -0,0 +1,132 @@
+package handlertest
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	// DefaultApplicationID is the ID of the application which receives the interactions.
+	DefaultApplicationID snowflake.ID = 1000000000000000001
+	// DefaultGuildID is the ID of the guild the interactions are sent in.
+	DefaultGuildID snowflake.ID = 1000000000000000002
+	// DefaultChannelID is the ID of the channel the interactions are sent in.
+	DefaultChannelID snowflake.ID = 1000000000000000003
+)
+
+// DefaultUser is the user who sends the interactions.
+var DefaultUser = discord.User{
+	ID:       1000000000000000004,
+	Username: "tester",
+}
+
+func defaultInteractionConfig() interactionConfig {
+	guildID := DefaultGuildID
+	return interactionConfig{
+		User:    DefaultUser,
+		GuildID: &guildID,
+		Locale:  discord.LocaleEnglishUS,
+	}
+}
+
+type interactionConfig struct {
+	User           discord.User
+	GuildID        *snowflake.ID
+	Roles          []snowflake.ID
+	Permissions    discord.Permissions
+	AppPermissions discord.Permissions
+	Channel        discord.MessageChannel
+	Locale         discord.Locale
+	GuildLocale    *discord.Locale
+	Entitlements   []discord.Entitlement
+	Message        *discord.Message
+}
+
+// InteractionConfigOpt is a functional option for configuring an interaction built by this package.
+type InteractionConfigOpt func(config *interactionConfig)
+
+func (c *interactionConfig) apply(opts []InteractionConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithUser sets the user who sends the interaction. Defaults to DefaultUser.
+func WithUser(user discord.User) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.User = user
+	}
+}
+
+// WithGuild sets the guild the interaction is sent in. Defaults to DefaultGuildID.
+func WithGuild(guildID snowflake.ID) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.GuildID = &guildID
+	}
+}
+
+// WithDM sends the interaction in the DM channel of the user instead of a guild.
+func WithDM() InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.GuildID = nil
+	}
+}
+
+// WithRoles sets the roles of the member who sends the interaction.
+func WithRoles(roleIDs ...snowflake.ID) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Roles = roleIDs
+	}
+}
+
+// WithPermissions sets the permissions of the member in the channel. Defaults to no permissions.
+func WithPermissions(permissions discord.Permissions) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Permissions = permissions
+	}
+}
+
+// WithAppPermissions sets the permissions of the application in the channel. Defaults to no permissions.
+func WithAppPermissions(permissions discord.Permissions) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.AppPermissions = permissions
+	}
+}
+
+// WithChannel overrides the channel the interaction is sent in.
+// Defaults to a text channel with DefaultChannelID, or a DM channel if WithDM is used.
+func WithChannel(channel discord.MessageChannel) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Channel = channel
+	}
+}
+
+// WithLocale sets the locale of the user. Defaults to discord.LocaleEnglishUS.
+func WithLocale(locale discord.Locale) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Locale = locale
+	}
+}
+
+// WithGuildLocale sets the locale of the guild.
+func WithGuildLocale(locale discord.Locale) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.GuildLocale = &locale
+	}
+}
+
+// WithEntitlements sets the entitlements of the user and guild.
+func WithEntitlements(entitlements ...discord.Entitlement) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Entitlements = entitlements
+	}
+}
+
+// WithMessage sets the message a component is attached to or a modal was opened from.
+// Component interactions default to an empty message sent by the application.
+func WithMessage(message discord.Message) InteractionConfigOpt {
+	return func(config *interactionConfig) {
+		config.Message = &message
+	}
+}
@@ -0,0 +1,264 @@
+package handlertest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var (
+	_ rest.Client = (*restClient)(nil)
+	_ rest.Rest   = (*recorderRest)(nil)
+)
+
+// interactionHandler is implemented by routers which return the error of the handler, like *handler.Mux.
+type interactionHandler interface {
+	HandleInteraction(e *events.InteractionCreate) error
+}
+
+// Do routes the interaction through the router and returns a new Recorder with the captured responses.
+func Do(router handler.Router, interaction discord.Interaction) *Recorder {
+	recorder := NewRecorder()
+	recorder.Do(router, interaction)
+	return recorder
+}
+
+// Request is a REST request which was made with the Recorder.Client.
+type Request struct {
+	Endpoint *rest.CompiledEndpoint
+	Body     any
+}
+
+// RestFunc handles a REST request made with the Recorder.Client. The response should be written into rsBody.
+type RestFunc func(endpoint *rest.CompiledEndpoint, rqBody any, rsBody any) error
+
+// NewRecorder returns a new Recorder with a fake bot.Client.
+func NewRecorder() *Recorder {
+	recorder := &Recorder{
+		state: events.NewInteractionResponseState(),
+	}
+	recorder.Client = &bot.Client{
+		ApplicationID: DefaultApplicationID,
+		Logger:        slog.New(slog.DiscardHandler),
+		Rest: &recorderRest{
+			Rest:     rest.New(&restClient{recorder: recorder}),
+			recorder: recorder,
+		},
+		Caches: cache.New(),
+	}
+	return recorder
+}
+
+// Recorder records the response, followup messages and updates of the original response of an interaction.
+// Other REST requests made with the Client are recorded as Request(s) and handled by the RestFunc.
+// A Recorder should only be used for a single interaction.
+type Recorder struct {
+	// Client is the fake bot.Client the interaction event uses. Its Caches can be filled before routing the interaction.
+	Client *bot.Client
+	// RestFunc handles REST requests which are not followup messages or updates of the original response.
+	// If nil, these requests succeed without a response.
+	RestFunc RestFunc
+
+	mu        sync.Mutex
+	state     *events.InteractionResponseState
+	response  *discord.InteractionResponse
+	followups []discord.MessageCreate
+	updates   []discord.MessageUpdate
+	requests  []Request
+	err       error
+}
+
+// Event returns a new events.InteractionCreate for the interaction which responds to the Recorder.
+func (r *Recorder) Event(interaction discord.Interaction) *events.InteractionCreate {
+	return &events.InteractionCreate{
+		GenericEvent:  events.NewGenericEvent(r.Client, 0, 0),
+		Interaction:   interaction,
+		Respond:       r.Respond,
+		ResponseState: r.state,
+	}
+}
+
+// Do routes the interaction through the router and records the error of the handler.
+// Errors are only recorded for routers with a HandleInteraction method, like *handler.Mux.
+func (r *Recorder) Do(router handler.Router, interaction discord.Interaction) {
+	e := r.Event(interaction)
+	h, ok := router.(interactionHandler)
+	if !ok {
+		router.OnEvent(e)
+		return
+	}
+
+	err := h.HandleInteraction(e)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+// Respond records the interaction response. Like Discord, it returns discord.ErrInteractionAlreadyReplied if the interaction was already responded to.
+func (r *Recorder) Respond(responseType discord.InteractionResponseType, data discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+	r.state.Mu.Lock()
+	defer r.state.Mu.Unlock()
+	if r.state.ResponseTypeValue != nil {
+		return discord.ErrInteractionAlreadyReplied
+	}
+	r.state.ResponseTypeValue = &responseType
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.response = &discord.InteractionResponse{
+		Type: responseType,
+		Data: data,
+	}
+	return nil
+}
+
+// Err returns the error returned by the handler.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Response returns the interaction response or nil if the interaction was not responded to.
+func (r *Recorder) Response() *discord.InteractionResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.response
+}
+
+// Message returns the message of a discord.InteractionResponseTypeCreateMessage response.
+func (r *Recorder) Message() (discord.MessageCreate, bool) {
+	return responseData[discord.MessageCreate](r.Response(), discord.InteractionResponseTypeCreateMessage)
+}
+
+// MessageUpdate returns the update of a discord.InteractionResponseTypeUpdateMessage response.
+func (r *Recorder) MessageUpdate() (discord.MessageUpdate, bool) {
+	return responseData[discord.MessageUpdate](r.Response(), discord.InteractionResponseTypeUpdateMessage)
+}
+
+// Modal returns the modal of a discord.InteractionResponseTypeModal response.
+func (r *Recorder) Modal() (discord.ModalCreate, bool) {
+	return responseData[discord.ModalCreate](r.Response(), discord.InteractionResponseTypeModal)
+}
+
+// AutocompleteChoices returns the choices of a discord.InteractionResponseTypeAutocompleteResult response.
+func (r *Recorder) AutocompleteChoices() ([]discord.AutocompleteChoice, bool) {
+	result, ok := responseData[discord.AutocompleteResult](r.Response(), discord.InteractionResponseTypeAutocompleteResult)
+	return result.Choices, ok
+}
+
+// Ephemeral returns whether the interaction was responded to with an ephemeral or deferred ephemeral message.
+func (r *Recorder) Ephemeral() bool {
+	response := r.Response()
+	if response == nil {
+		return false
+	}
+	messageCreate, ok := response.Data.(discord.MessageCreate)
+	return ok && messageCreate.Flags.Has(discord.MessageFlagEphemeral)
+}
+
+// Followups returns the followup messages which were created.
+func (r *Recorder) Followups() []discord.MessageCreate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]discord.MessageCreate(nil), r.followups...)
+}
+
+// Updates returns the updates of the original response, e.g. after the interaction was deferred.
+func (r *Recorder) Updates() []discord.MessageUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]discord.MessageUpdate(nil), r.updates...)
+}
+
+// Requests returns the other REST requests which were made with the Client.
+func (r *Recorder) Requests() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Request(nil), r.requests...)
+}
+
+func responseData[T discord.InteractionResponseData](response *discord.InteractionResponse, responseType discord.InteractionResponseType) (T, bool) {
+	var zero T
+	if response == nil || response.Type != responseType {
+		return zero, false
+	}
+	data, ok := response.Data.(T)
+	return data, ok
+}
+
+// recorderRest records followup messages and updates of the original response and passes other requests to the restClient.
+type recorderRest struct {
+	rest.Rest
+	recorder *Recorder
+}
+
+func (r *recorderRest) CreateFollowupMessage(_ snowflake.ID, _ string, messageCreate discord.MessageCreate, _ ...rest.RequestOpt) (*discord.Message, error) {
+	r.recorder.mu.Lock()
+	r.recorder.followups = append(r.recorder.followups, messageCreate)
+	r.recorder.mu.Unlock()
+	return newMessage(messageCreate)
+}
+
+func (r *recorderRest) UpdateInteractionResponse(_ snowflake.ID, _ string, messageUpdate discord.MessageUpdate, _ ...rest.RequestOpt) (*discord.Message, error) {
+	r.recorder.mu.Lock()
+	r.recorder.updates = append(r.recorder.updates, messageUpdate)
+	r.recorder.mu.Unlock()
+	return newMessage(messageUpdate)
+}
+
+// newMessage returns the message Discord would return for the given discord.MessageCreate or discord.MessageUpdate.
+func newMessage(v any) (*discord.Message, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var message discord.Message
+	if err = json.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+	message.ID = newID()
+	message.ChannelID = DefaultChannelID
+	message.Author = discord.User{ID: DefaultApplicationID, Username: "application", Bot: true}
+	message.CreatedAt = time.Now()
+	return &message, nil
+}
+
+// restClient records all requests and passes them to the RestFunc of the Recorder instead of sending them to Discord.
+type restClient struct {
+	recorder *Recorder
+}
+
+func (c *restClient) HTTPClient() *http.Client {
+	return http.DefaultClient
+}
+
+func (c *restClient) RateLimiter() rest.RateLimiter {
+	return nil
+}
+
+func (c *restClient) Close(_ context.Context) {}
+
+func (c *restClient) Do(endpoint *rest.CompiledEndpoint, rqBody any, rsBody any, _ ...rest.RequestOpt) error {
+	c.recorder.mu.Lock()
+	c.recorder.requests = append(c.recorder.requests, Request{Endpoint: endpoint, Body: rqBody})
+	restFunc := c.recorder.RestFunc
+	c.recorder.mu.Unlock()
+
+	if restFunc == nil {
+		return nil
+	}
+	return restFunc(endpoint, rqBody, rsBody)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
@@ -15,6 +16,11 @@ type InteractionEvent struct {
 	*events.InteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// Pattern is the full pattern of the route which handles the interaction, e.g. /mod/ban or /page/{id}.
+	Pattern string
+	// Translator translates messages into the locale of the user with the locale of the guild as fallback.
+	// It is nil if no i18n.Bundle is set with Mux.Bundle.
+	Translator i18n.Translator
 }
 
 // CreateMessage responds to the interaction with a new message.
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// CooldownScope decides who shares a cooldown.
+type CooldownScope int
+
+const (
+	// CooldownScopeUser gives each user its own cooldown.
+	CooldownScopeUser CooldownScope = iota
+	// CooldownScopeGuild shares the cooldown between all users of a guild. Outside of guilds it falls back to CooldownScopeChannel.
+	CooldownScopeGuild
+	// CooldownScopeChannel shares the cooldown between all users of a channel.
+	CooldownScopeChannel
+	// CooldownScopeGlobal shares the cooldown between all users.
+	CooldownScopeGlobal
+)
+
+func (s CooldownScope) String() string {
+	switch s {
+	case CooldownScopeUser:
+		return "user"
+	case CooldownScopeGuild:
+		return "guild"
+	case CooldownScopeChannel:
+		return "channel"
+	case CooldownScopeGlobal:
+		return "global"
+	default:
+		return "unknown"
+	}
+}
+
+// CooldownResponseFunc is called when an interaction is on cooldown and responds to it.
+type CooldownResponseFunc func(event *handler.InteractionEvent, retryAfter time.Duration) error
+
+// TextCooldownResponseFunc is called when a text command is on cooldown and responds to it.
+type TextCooldownResponseFunc func(event *handler.TextCommandEvent, retryAfter time.Duration) error
+
+var defaultCooldownResponse CooldownResponseFunc = func(event *handler.InteractionEvent, retryAfter time.Duration) error {
+	return event.CreateMessage(discord.MessageCreate{
+		Content: cooldownContent(retryAfter),
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
+
+var defaultTextCooldownResponse TextCooldownResponseFunc = func(event *handler.TextCommandEvent, retryAfter time.Duration) error {
+	_, err := event.Reply(discord.MessageCreate{
+		Content: cooldownContent(retryAfter),
+	})
+	return err
+}
+
+func cooldownContent(retryAfter time.Duration) string {
+	return fmt.Sprintf("You are on cooldown. Try again %s.", discord.TimestampStyleRelative.FormatTime(time.Now().Add(retryAfter)))
+}
+
+func defaultCooldownConfig() cooldownConfig {
+	return cooldownConfig{
+		Scope:        CooldownScopeUser,
+		Burst:        1,
+		Store:        NewMemoryCooldownStore(),
+		Response:     defaultCooldownResponse,
+		TextResponse: defaultTextCooldownResponse,
+	}
+}
+
+type cooldownConfig struct {
+	Scope             CooldownScope
+	Burst             int
+	Store             CooldownStore
+	Response          CooldownResponseFunc
+	TextResponse      TextCooldownResponseFunc
+	Key               func(event *handler.InteractionEvent) string
+	ExemptRoles       []snowflake.ID
+	ExemptPermissions discord.Permissions
+}
+
+// CooldownConfigOpt is a functional option for configuring the Cooldown middleware.
+type CooldownConfigOpt func(config *cooldownConfig)
+
+func (c *cooldownConfig) apply(opts []CooldownConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithCooldownScope sets who shares the cooldown. Defaults to CooldownScopeUser.
+func WithCooldownScope(scope CooldownScope) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Scope = scope
+	}
+}
+
+// WithCooldownBurst sets how many uses are allowed per period. Defaults to 1.
+func WithCooldownBurst(burst int) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Burst = burst
+	}
+}
+
+// WithCooldownStore overrides the in-memory CooldownStore. Pass the same CooldownStore to multiple Cooldown middlewares to share it.
+func WithCooldownStore(store CooldownStore) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Store = store
+	}
+}
+
+// WithCooldownResponse overrides the response which is sent when an interaction is on cooldown.
+func WithCooldownResponse(response CooldownResponseFunc) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Response = response
+	}
+}
+
+// WithTextCooldownResponse overrides the response which is sent when a text command is on cooldown.
+func WithTextCooldownResponse(response TextCooldownResponseFunc) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.TextResponse = response
+	}
+}
+
+// WithCooldownKey overrides the key of the route the cooldown is tracked for. Defaults to handler.InteractionEvent.Pattern.
+func WithCooldownKey(key func(event *handler.InteractionEvent) string) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Key = key
+	}
+}
+
+// WithCooldownExemptRoles exempts members with any of the given roles from the cooldown.
+func WithCooldownExemptRoles(roleIDs ...snowflake.ID) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.ExemptRoles = append(config.ExemptRoles, roleIDs...)
+	}
+}
+
+// WithCooldownExemptPermissions exempts members with all the given permissions in the channel from the cooldown.
+func WithCooldownExemptPermissions(permissions discord.Permissions) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.ExemptPermissions = permissions
+	}
+}
+
+type cooldownCtxKey struct{}
+
+type cooldownReset struct {
+	store CooldownStore
+	key   string
+}
+
+// ResetCooldown resets the cooldown which was used by the interaction or text command of the given context, e.g. when the command failed.
+// The context is the Ctx of the event passed to the handler. It returns false if neither the Cooldown nor the TextCooldown middleware was used.
+func ResetCooldown(ctx context.Context) (bool, error) {
+	reset, ok := ctx.Value(cooldownCtxKey{}).(cooldownReset)
+	if !ok {
+		return false, nil
+	}
+	return true, reset.store.Reset(ctx, reset.key)
+}
+
+// Cooldown is a middleware which allows each route to be used once per period. Autocomplete interactions are not limited.
+// The cooldown is tracked per route pattern and can be configured with CooldownConfigOpt(s),
+// e.g. to allow multiple uses per period, change who shares the cooldown or exempt roles and permissions.
+// Interactions on cooldown are responded to with an ephemeral message telling the user when to try again.
+func Cooldown(period time.Duration, opts ...CooldownConfigOpt) handler.Middleware {
+	cfg := defaultCooldownConfig()
+	cfg.apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if event.Type() == discord.InteractionTypeAutocomplete {
+				return next(event)
+			}
+			if member := event.Member(); member != nil && cfg.exempt(member.RoleIDs, member.Permissions) {
+				return next(event)
+			}
+
+			routeKey := event.Pattern
+			if cfg.Key != nil {
+				routeKey = cfg.Key(event)
+			}
+			key, retryAfter, err := cfg.take(event.Ctx, cooldownScopeID(cfg.Scope, event), routeKey, period)
+			if err != nil {
+				return err
+			}
+			if retryAfter > 0 {
+				return cfg.Response(event, retryAfter)
+			}
+
+			event.Ctx = context.WithValue(event.Ctx, cooldownCtxKey{}, cooldownReset{store: cfg.Store, key: key})
+			return next(event)
+		}
+	}
+}
+
+// TextCooldown is the Cooldown middleware for text commands. The cooldown is tracked per pattern of the text commands,
+// so a text command shares the cooldown with the application command of the same name if both use the same CooldownStore.
+// Text commands on cooldown are replied to with a message telling the user when to try again.
+// WithCooldownResponse and WithCooldownKey are ignored, use WithTextCooldownResponse instead.
+func TextCooldown(period time.Duration, opts ...CooldownConfigOpt) handler.TextMiddleware {
+	cfg := defaultCooldownConfig()
+	cfg.apply(opts)
+
+	return func(next handler.TextHandler) handler.TextHandler {
+		return func(event *handler.TextCommandEvent) error {
+			if member := event.Message.Member; member != nil && cfg.exempt(member.RoleIDs, event.MemberPermissions()) {
+				return next(event)
+			}
+
+			key, retryAfter, err := cfg.take(event.Ctx, textCooldownScopeID(cfg.Scope, event), event.Pattern, period)
+			if err != nil {
+				return err
+			}
+			if retryAfter > 0 {
+				return cfg.TextResponse(event, retryAfter)
+			}
+
+			event.Ctx = context.WithValue(event.Ctx, cooldownCtxKey{}, cooldownReset{store: cfg.Store, key: key})
+			return next(event)
+		}
+	}
+}
+
+// take uses the cooldown of the scope and route and returns its key and how long to wait if it is on cooldown.
+func (c cooldownConfig) take(ctx context.Context, scopeID snowflake.ID, route string, period time.Duration) (string, time.Duration, error) {
+	key := fmt.Sprintf("%s:%s:%s", c.Scope, scopeID, route)
+	retryAfter, err := c.Store.Take(ctx, key, c.Burst, period)
+	return key, retryAfter, err
+}
+
+func (c cooldownConfig) exempt(roleIDs []snowflake.ID, permissions discord.Permissions) bool {
+	if c.ExemptPermissions != 0 && permissions.Has(c.ExemptPermissions) {
+		return true
+	}
+	for _, roleID := range c.ExemptRoles {
+		if slices.Contains(roleIDs, roleID) {
+			return true
+		}
+	}
+	return false
+}
+
+func cooldownScopeID(scope CooldownScope, event *handler.InteractionEvent) snowflake.ID {
+	switch scope {
+	case CooldownScopeGuild:
+		if guildID := event.GuildID(); guildID != nil {
+			return *guildID
+		}
+		fallthrough
+	case CooldownScopeChannel:
+		if channel := event.Channel(); channel.MessageChannel != nil {
+			return channel.ID()
+		}
+		return event.User().ID
+	case CooldownScopeGlobal:
+		return 0
+	default:
+		return event.User().ID
+	}
+}
+
+func textCooldownScopeID(scope CooldownScope, event *handler.TextCommandEvent) snowflake.ID {
+	switch scope {
+	case CooldownScopeGuild:
+		if event.GuildID != nil {
+			return *event.GuildID
+		}
+		return event.ChannelID
+	case CooldownScopeChannel:
+		return event.ChannelID
+	case CooldownScopeGlobal:
+		return 0
+	default:
+		return event.Message.Author.ID
+	}
+}
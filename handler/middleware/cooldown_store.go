@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ CooldownStore = (*memoryCooldownStore)(nil)
+
+// CooldownStore stores the state of cooldowns. Implement it to share cooldowns between processes, e.g. with Redis.
+type CooldownStore interface {
+	// Take tries to use the cooldown with the given key, which allows burst uses per period.
+	// It returns 0 if the use is allowed or the duration after which the next use is allowed.
+	Take(ctx context.Context, key string, burst int, period time.Duration) (time.Duration, error)
+
+	// Reset resets the cooldown with the given key.
+	Reset(ctx context.Context, key string) error
+}
+
+// NewMemoryCooldownStore returns a new in-memory CooldownStore.
+// It implements the generic cell rate algorithm, so uses are refilled continuously at a rate of burst per period.
+func NewMemoryCooldownStore() CooldownStore {
+	return &memoryCooldownStore{
+		now:       time.Now,
+		cooldowns: make(map[string]time.Time),
+	}
+}
+
+type memoryCooldownStore struct {
+	now func() time.Time
+
+	mu          sync.Mutex
+	cooldowns   map[string]time.Time
+	lastCleanup time.Time
+}
+
+func (s *memoryCooldownStore) Take(_ context.Context, key string, burst int, period time.Duration) (time.Duration, error) {
+	burst = max(burst, 1)
+	interval := period / time.Duration(burst)
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanup(now)
+
+	// tat is the theoretical arrival time at which the cooldown is fully refilled
+	tat := s.cooldowns[key]
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(interval)
+	if allowAt := newTat.Add(-period); allowAt.After(now) {
+		return allowAt.Sub(now), nil
+	}
+	s.cooldowns[key] = newTat
+	return 0, nil
+}
+
+func (s *memoryCooldownStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cooldowns, key)
+	return nil
+}
+
+// cleanup removes all fully refilled cooldowns at most once per minute.
+func (s *memoryCooldownStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < time.Minute {
+		return
+	}
+	s.lastCleanup = now
+	for key, tat := range s.cooldowns {
+		if tat.Before(now) {
+			delete(s.cooldowns, key)
+		}
+	}
+}
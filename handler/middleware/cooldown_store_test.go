@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCooldownStore(t *testing.T) {
+	type take struct {
+		at         time.Duration
+		reset      bool
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name   string
+		burst  int
+		period time.Duration
+		takes  []take
+	}{
+		{
+			name:   "single use",
+			burst:  1,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: 3 * time.Second, retryAfter: 7 * time.Second},
+				{at: 10 * time.Second},
+			},
+		},
+		{
+			name:   "burst",
+			burst:  2,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: 0},
+				{at: 0, retryAfter: 5 * time.Second},
+			},
+		},
+		{
+			name:   "refill",
+			burst:  2,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: 0},
+				// one use is refilled every period / burst
+				{at: 5 * time.Second},
+				{at: 6 * time.Second, retryAfter: 4 * time.Second},
+				// fully refilled, so the burst is available again
+				{at: 30 * time.Second},
+				{at: 30 * time.Second},
+				{at: 30 * time.Second, retryAfter: 5 * time.Second},
+			},
+		},
+		{
+			name:   "denied uses don't count",
+			burst:  1,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: 5 * time.Second, retryAfter: 5 * time.Second},
+				{at: 9 * time.Second, retryAfter: time.Second},
+				{at: 10 * time.Second},
+			},
+		},
+		{
+			name:   "burst defaults to one",
+			burst:  0,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: 0, retryAfter: 10 * time.Second},
+			},
+		},
+		{
+			name:   "reset",
+			burst:  1,
+			period: 10 * time.Second,
+			takes: []take{
+				{at: 0},
+				{at: time.Second, retryAfter: 9 * time.Second},
+				{at: time.Second, reset: true},
+				{at: 2 * time.Second, retryAfter: 9 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			var now time.Time
+			s := NewMemoryCooldownStore().(*memoryCooldownStore)
+			s.now = func() time.Time { return now }
+
+			for i, take := range tt.takes {
+				now = start.Add(take.at)
+				if take.reset {
+					if err := s.Reset(context.Background(), "key"); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+				retryAfter, err := s.Take(context.Background(), "key", tt.burst, tt.period)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if retryAfter != take.retryAfter {
+					t.Errorf("take %d at %s: expected retry after %s, got %s", i, take.at, take.retryAfter, retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryCooldownStoreCleanup(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryCooldownStore().(*memoryCooldownStore)
+	s.now = func() time.Time { return now }
+
+	_, _ = s.Take(context.Background(), "expired", 1, time.Second)
+	_, _ = s.Take(context.Background(), "active", 1, time.Hour)
+
+	now = now.Add(2 * time.Minute)
+	_, _ = s.Take(context.Background(), "other", 1, time.Second)
+	if _, ok := s.cooldowns["expired"]; ok {
+		t.Errorf("expected refilled cooldown to be removed")
+	}
+	if _, ok := s.cooldowns["active"]; !ok {
+		t.Errorf("expected active cooldown to be kept")
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+func TestCooldown(t *testing.T) {
+	const exemptRoleID = 10
+	other := discord.User{ID: 20, Username: "other"}
+
+	r := handler.New()
+	r.Use(Cooldown(time.Minute, WithCooldownExemptRoles(exemptRoleID)))
+	r.SlashCommand("/ping", func(_ discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: "pong"})
+	})
+	r.SlashCommand("/retry", func(_ discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if ok, err := ResetCooldown(e.Ctx); !ok || err != nil {
+			t.Errorf("expected cooldown to be reset, got %t, %v", ok, err)
+		}
+		return e.CreateMessage(discord.MessageCreate{Content: "failed"})
+	})
+
+	onCooldown := func(rec *handlertest.Recorder) bool {
+		message, ok := rec.Message()
+		return ok && strings.HasPrefix(message.Content, "You are on cooldown") && rec.Ephemeral()
+	}
+
+	if rec := handlertest.Do(r, handlertest.NewSlashCommand("/ping", nil)); onCooldown(rec) {
+		t.Errorf("expected first use to be allowed, got %+v", rec.Response())
+	}
+	if rec := handlertest.Do(r, handlertest.NewSlashCommand("/ping", nil)); !onCooldown(rec) {
+		t.Errorf("expected second use to be on cooldown, got %+v", rec.Response())
+	}
+	if rec := handlertest.Do(r, handlertest.NewSlashCommand("/ping", nil, handlertest.WithUser(other))); onCooldown(rec) {
+		t.Errorf("expected other user to have its own cooldown, got %+v", rec.Response())
+	}
+	if rec := handlertest.Do(r, handlertest.NewSlashCommand("/ping", nil, handlertest.WithRoles(exemptRoleID))); onCooldown(rec) {
+		t.Errorf("expected exempt role to skip the cooldown, got %+v", rec.Response())
+	}
+
+	for range 2 {
+		if rec := handlertest.Do(r, handlertest.NewSlashCommand("/retry", nil)); onCooldown(rec) {
+			t.Errorf("expected reset cooldown to allow the next use, got %+v", rec.Response())
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// DenialReason is the reason why a Check denied an interaction.
+type DenialReason int
+
+const (
+	// DenialReasonGuildOnly is used when the interaction is not in a guild.
+	DenialReasonGuildOnly DenialReason = iota + 1
+	// DenialReasonMissingPermissions is used when the member is missing permissions.
+	DenialReasonMissingPermissions
+	// DenialReasonBotMissingPermissions is used when the application is missing permissions in the channel.
+	DenialReasonBotMissingPermissions
+	// DenialReasonNSFWOnly is used when the channel is not age-restricted.
+	DenialReasonNSFWOnly
+	// DenialReasonOwnerOnly is used when the user is not the owner or a team member of the application.
+	DenialReasonOwnerOnly
+	// DenialReasonMissingEntitlement is used when the user or guild has none of the required entitlements.
+	DenialReasonMissingEntitlement
+)
+
+func (r DenialReason) String() string {
+	switch r {
+	case DenialReasonGuildOnly:
+		return "guild only"
+	case DenialReasonMissingPermissions:
+		return "missing permissions"
+	case DenialReasonBotMissingPermissions:
+		return "bot missing permissions"
+	case DenialReasonNSFWOnly:
+		return "nsfw only"
+	case DenialReasonOwnerOnly:
+		return "owner only"
+	case DenialReasonMissingEntitlement:
+		return "missing entitlement"
+	default:
+		return "unknown"
+	}
+}
+
+// DeniedError is returned by a Check to deny an interaction. The Guard middleware responds to the interaction
+// and returns the DeniedError, so it is reported to the handler.ErrorHandler.
+type DeniedError struct {
+	Reason DenialReason
+	// Permissions are the missing permissions for DenialReasonMissingPermissions and DenialReasonBotMissingPermissions.
+	Permissions discord.Permissions
+	// SKUIDs are the required SKUs for DenialReasonMissingEntitlement.
+	SKUIDs []snowflake.ID
+}
+
+func (e *DeniedError) Error() string {
+	if e.Permissions != 0 {
+		return fmt.Sprintf("interaction denied: %s: %s", e.Reason, e.Permissions)
+	}
+	return fmt.Sprintf("interaction denied: %s", e.Reason)
+}
+
+// EventCheck checks whether an event of type E is allowed. It returns a *DeniedError to deny the event.
+// Other errors are returned by the guard middleware without responding to the event.
+type EventCheck[E any] func(event E) error
+
+// Check checks whether an interaction is allowed. It returns a *DeniedError to deny the interaction.
+// Other errors are returned by the Guard middleware without responding to the interaction.
+type Check = EventCheck[*handler.InteractionEvent]
+
+// DeniedResponseFunc responds to an event of type E which was denied by an EventCheck.
+type DeniedResponseFunc[E any] func(event E, err *DeniedError) error
+
+// GuardResponseFunc responds to an interaction which was denied by a Check.
+type GuardResponseFunc = DeniedResponseFunc[*handler.InteractionEvent]
+
+// DefaultGuardResponse responds with an ephemeral message which explains the DeniedError.
+// Autocomplete interactions are responded to with no choices.
+var DefaultGuardResponse GuardResponseFunc = func(event *handler.InteractionEvent, err *DeniedError) error {
+	if event.Type() == discord.InteractionTypeAutocomplete {
+		return event.AutocompleteResult(nil)
+	}
+
+	var components []discord.LayoutComponent
+	if err.Reason == DenialReasonMissingEntitlement {
+		components = premiumButtons(err.SKUIDs)
+	}
+	return event.CreateMessage(discord.MessageCreate{
+		Content:    deniedContent(err),
+		Flags:      discord.MessageFlagEphemeral,
+		Components: components,
+	})
+}
+
+// deniedContent returns a message which explains the DeniedError to the user.
+func deniedContent(err *DeniedError) string {
+	switch err.Reason {
+	case DenialReasonGuildOnly:
+		return "This can only be used in a server."
+	case DenialReasonMissingPermissions:
+		return fmt.Sprintf("You are missing the following permissions: %s", err.Permissions)
+	case DenialReasonBotMissingPermissions:
+		return fmt.Sprintf("I am missing the following permissions in this channel: %s", err.Permissions)
+	case DenialReasonNSFWOnly:
+		return "This can only be used in age-restricted channels."
+	case DenialReasonOwnerOnly:
+		return "This can only be used by the owners of this application."
+	case DenialReasonMissingEntitlement:
+		return "This requires a premium subscription."
+	default:
+		return "You are not allowed to use this."
+	}
+}
+
+func premiumButtons(skuIDs []snowflake.ID) []discord.LayoutComponent {
+	if len(skuIDs) == 0 {
+		return nil
+	}
+	buttons := make([]discord.InteractiveComponent, 0, min(len(skuIDs), 5))
+	for _, skuID := range skuIDs[:min(len(skuIDs), 5)] {
+		buttons = append(buttons, discord.NewPremiumButton(skuID))
+	}
+	return []discord.LayoutComponent{discord.NewActionRow(buttons...)}
+}
+
+// Guard is a middleware which runs the given Check(s) in order and denies the interaction on the first *DeniedError
+// with the DefaultGuardResponse. The DeniedError is returned afterward, so it is reported to the handler.ErrorHandler.
+func Guard(checks ...Check) handler.Middleware {
+	return GuardWith(DefaultGuardResponse, checks...)
+}
+
+// GuardWith is like Guard but responds to denied interactions with the given GuardResponseFunc.
+func GuardWith(response GuardResponseFunc, checks ...Check) handler.Middleware {
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if err := runChecks(event, response, checks); err != nil {
+				return err
+			}
+			return next(event)
+		}
+	}
+}
+
+// runChecks runs the checks in order and responds to the first *DeniedError, which is returned afterward.
+// It is shared by GuardWith and TextGuardWith.
+func runChecks[E any](event E, response DeniedResponseFunc[E], checks []EventCheck[E]) error {
+	for _, check := range checks {
+		err := check(event)
+		if err == nil {
+			continue
+		}
+		var deniedErr *DeniedError
+		if !errors.As(err, &deniedErr) {
+			return err
+		}
+		if respondErr := response(event, deniedErr); respondErr != nil {
+			return errors.Join(deniedErr, respondErr)
+		}
+		return deniedErr
+	}
+	return nil
+}
+
+// AnyOf returns an EventCheck which allows the event if any of the given EventCheck(s) allows it.
+// It works for Check(s) and TextCheck(s). If all EventCheck(s) deny the event, the *DeniedError of the first EventCheck is returned.
+func AnyOf[E any](checks ...EventCheck[E]) EventCheck[E] {
+	return func(event E) error {
+		var firstErr error
+		for _, check := range checks {
+			err := check(event)
+			if err == nil {
+				return nil
+			}
+			var deniedErr *DeniedError
+			if !errors.As(err, &deniedErr) {
+				return err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+// IsGuild is a Check which denies interactions outside of guilds.
+func IsGuild(event *handler.InteractionEvent) error {
+	if event.GuildID() == nil {
+		return &DeniedError{Reason: DenialReasonGuildOnly}
+	}
+	return nil
+}
+
+// HasPermissions returns a Check which denies interactions of users missing any of the given permissions in the channel.
+// Interactions outside of guilds are denied with DenialReasonGuildOnly.
+func HasPermissions(permissions discord.Permissions) Check {
+	return func(event *handler.InteractionEvent) error {
+		member := event.Member()
+		if member == nil {
+			return &DeniedError{Reason: DenialReasonGuildOnly}
+		}
+		if missing := permissions &^ member.Permissions; missing != 0 && !member.Permissions.Has(discord.PermissionAdministrator) {
+			return &DeniedError{Reason: DenialReasonMissingPermissions, Permissions: missing}
+		}
+		return nil
+	}
+}
+
+// BotHasPermissions returns a Check which denies interactions if the application is missing any of the given permissions in the channel.
+// Interactions outside of guilds are always allowed like with TextBotHasPermissions, as guild permissions don't apply there.
+// In guilds, missing app permissions are treated as no permissions.
+func BotHasPermissions(permissions discord.Permissions) Check {
+	return func(event *handler.InteractionEvent) error {
+		if event.GuildID() == nil {
+			return nil
+		}
+		var appPermissions discord.Permissions
+		if p := event.AppPermissions(); p != nil {
+			appPermissions = *p
+		}
+		if missing := permissions &^ appPermissions; missing != 0 && !appPermissions.Has(discord.PermissionAdministrator) {
+			return &DeniedError{Reason: DenialReasonBotMissingPermissions, Permissions: missing}
+		}
+		return nil
+	}
+}
+
+// IsNSFWChannel is a Check which denies interactions outside of age-restricted channels.
+// Threads are checked by their parent channel, which needs to be cached.
+func IsNSFWChannel(event *handler.InteractionEvent) error {
+	if channel := event.Channel(); channel.MessageChannel == nil || !isNSFWChannel(event.Client(), channel.MessageChannel) {
+		return &DeniedError{Reason: DenialReasonNSFWOnly}
+	}
+	return nil
+}
+
+// isNSFWChannel returns whether the channel or the parent channel of a thread is age-restricted.
+func isNSFWChannel(client *bot.Client, channel discord.Channel) bool {
+	switch c := channel.(type) {
+	case discord.GuildThread:
+		if c.ParentID() != nil {
+			if parent, ok := client.Caches.Channel(*c.ParentID()); ok {
+				if nsfwChannel, ok := parent.(interface{ NSFW() bool }); ok && nsfwChannel.NSFW() {
+					return true
+				}
+			}
+		}
+	case discord.GuildMessageChannel:
+		return c.NSFW()
+	}
+	return false
+}
+
+// HasEntitlement returns a Check which denies interactions if the user or guild has no entitlement for any of the given SKUs.
+// The DefaultGuardResponse shows premium buttons for the SKUs.
+func HasEntitlement(skuIDs ...snowflake.ID) Check {
+	return func(event *handler.InteractionEvent) error {
+		for _, entitlement := range event.Entitlements() {
+			if !entitlement.Deleted && slices.Contains(skuIDs, entitlement.SkuID) {
+				return nil
+			}
+		}
+		return &DeniedError{Reason: DenialReasonMissingEntitlement, SKUIDs: skuIDs}
+	}
+}
+
+// IsApplicationOwner returns a Check which denies interactions of users who are neither the owner nor an accepted team member of the application.
+// The owners are fetched once with the first interaction and cached afterward.
+func IsApplicationOwner() Check {
+	var owners applicationOwners
+	return func(event *handler.InteractionEvent) error {
+		return owners.check(event.Client(), event.User().ID)
+	}
+}
+
+// applicationOwners fetches the owners of the application once and caches them afterward.
+type applicationOwners struct {
+	mu       sync.Mutex
+	fetched  bool
+	ownerIDs []snowflake.ID
+}
+
+func (o *applicationOwners) check(client *bot.Client, userID snowflake.ID) error {
+	ownerIDs, err := o.owners(client)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(ownerIDs, userID) {
+		return &DeniedError{Reason: DenialReasonOwnerOnly}
+	}
+	return nil
+}
+
+// owners returns the cached owners or fetches them without holding o.mu, so a slow request doesn't block other checks.
+// Concurrent first checks may fetch the owners more than once.
+func (o *applicationOwners) owners(client *bot.Client) ([]snowflake.ID, error) {
+	o.mu.Lock()
+	if o.fetched {
+		defer o.mu.Unlock()
+		return o.ownerIDs, nil
+	}
+	o.mu.Unlock()
+
+	application, err := client.Rest.GetCurrentApplication()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application owners: %w", err)
+	}
+	ownerIDs := applicationOwnerIDs(*application)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ownerIDs = ownerIDs
+	o.fetched = true
+	return ownerIDs, nil
+}
+
+func applicationOwnerIDs(application discord.Application) []snowflake.ID {
+	if application.Team == nil {
+		if application.Owner == nil {
+			return nil
+		}
+		return []snowflake.ID{application.Owner.ID}
+	}
+	ownerIDs := []snowflake.ID{application.Team.OwnerID}
+	for _, member := range application.Team.Members {
+		if member.MembershipState == discord.MembershipStateAccepted {
+			ownerIDs = append(ownerIDs, member.User.ID)
+		}
+	}
+	return ownerIDs
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// applicationRestFunc responds to rest.GetCurrentApplication with an application owned by the given user.
+func applicationRestFunc(ownerID snowflake.ID) handlertest.RestFunc {
+	return func(endpoint *rest.CompiledEndpoint, _ any, rsBody any) error {
+		if endpoint.Endpoint != rest.GetCurrentApplication {
+			return errors.New("unexpected request")
+		}
+		data, err := json.Marshal(discord.Application{ID: handlertest.DefaultApplicationID, Owner: &discord.User{ID: ownerID}})
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, rsBody)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	const skuID snowflake.ID = 50
+	errCheck := errors.New("check failed")
+	deny := func(reason DenialReason) Check {
+		return func(*handler.InteractionEvent) error {
+			return &DeniedError{Reason: reason}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		check    Check
+		opts     []handlertest.InteractionConfigOpt
+		restFunc handlertest.RestFunc
+		reason   DenialReason
+		err      error
+	}{
+		{name: "guild", check: IsGuild},
+		{name: "not guild", check: IsGuild, opts: []handlertest.InteractionConfigOpt{handlertest.WithDM()}, reason: DenialReasonGuildOnly},
+		{
+			name:  "permissions",
+			check: HasPermissions(discord.PermissionBanMembers),
+			opts:  []handlertest.InteractionConfigOpt{handlertest.WithPermissions(discord.PermissionBanMembers | discord.PermissionKickMembers)},
+		},
+		{
+			name:  "administrator",
+			check: HasPermissions(discord.PermissionBanMembers),
+			opts:  []handlertest.InteractionConfigOpt{handlertest.WithPermissions(discord.PermissionAdministrator)},
+		},
+		{
+			name:   "missing permissions",
+			check:  HasPermissions(discord.PermissionBanMembers),
+			opts:   []handlertest.InteractionConfigOpt{handlertest.WithPermissions(discord.PermissionKickMembers)},
+			reason: DenialReasonMissingPermissions,
+		},
+		{name: "permissions in DM", check: HasPermissions(discord.PermissionBanMembers), opts: []handlertest.InteractionConfigOpt{handlertest.WithDM()}, reason: DenialReasonGuildOnly},
+		{
+			name:  "bot permissions",
+			check: BotHasPermissions(discord.PermissionManageRoles),
+			opts:  []handlertest.InteractionConfigOpt{handlertest.WithAppPermissions(discord.PermissionManageRoles)},
+		},
+		{
+			name:   "bot missing permissions",
+			check:  BotHasPermissions(discord.PermissionManageRoles),
+			opts:   []handlertest.InteractionConfigOpt{handlertest.WithAppPermissions(discord.PermissionSendMessages)},
+			reason: DenialReasonBotMissingPermissions,
+		},
+		{
+			name:  "bot permissions in DM",
+			check: BotHasPermissions(discord.PermissionManageRoles),
+			opts:  []handlertest.InteractionConfigOpt{handlertest.WithDM()},
+		},
+		{
+			name:  "entitlement",
+			check: HasEntitlement(skuID),
+			opts:  []handlertest.InteractionConfigOpt{handlertest.WithEntitlements(discord.Entitlement{SkuID: skuID})},
+		},
+		{
+			name:   "deleted entitlement",
+			check:  HasEntitlement(skuID),
+			opts:   []handlertest.InteractionConfigOpt{handlertest.WithEntitlements(discord.Entitlement{SkuID: skuID, Deleted: true})},
+			reason: DenialReasonMissingEntitlement,
+		},
+		{name: "missing entitlement", check: HasEntitlement(skuID), reason: DenialReasonMissingEntitlement},
+		{name: "application owner", check: IsApplicationOwner(), restFunc: applicationRestFunc(handlertest.DefaultUser.ID)},
+		{name: "not application owner", check: IsApplicationOwner(), restFunc: applicationRestFunc(1), reason: DenialReasonOwnerOnly},
+		{name: "any of allows", check: AnyOf(deny(DenialReasonNSFWOnly), IsGuild)},
+		{name: "any of denies with first", check: AnyOf(deny(DenialReasonNSFWOnly), deny(DenialReasonOwnerOnly)), reason: DenialReasonNSFWOnly},
+		{name: "any of returns other errors", check: AnyOf(deny(DenialReasonNSFWOnly), func(*handler.InteractionEvent) error { return errCheck }), err: errCheck},
+		{name: "other errors", check: func(*handler.InteractionEvent) error { return errCheck }, err: errCheck},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handledErr error
+			r := handler.New()
+			r.Error(func(_ *handler.InteractionEvent, err error) {
+				handledErr = err
+			})
+			r.Use(Guard(tt.check))
+			r.SlashCommand("/cmd", func(_ discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+				return e.CreateMessage(discord.MessageCreate{Content: "allowed"})
+			})
+
+			rec := handlertest.NewRecorder()
+			rec.RestFunc = tt.restFunc
+			r.OnEvent(rec.Event(handlertest.NewSlashCommand("/cmd", nil, tt.opts...)))
+			message, _ := rec.Message()
+
+			switch {
+			case tt.err != nil:
+				if !errors.Is(handledErr, tt.err) {
+					t.Errorf("expected error %v, got %v", tt.err, handledErr)
+				}
+				if rec.Response() != nil {
+					t.Errorf("expected no response, got %+v", rec.Response())
+				}
+
+			case tt.reason != 0:
+				var deniedErr *DeniedError
+				if !errors.As(handledErr, &deniedErr) || deniedErr.Reason != tt.reason {
+					t.Fatalf("expected denied error with reason %s, got %v", tt.reason, handledErr)
+				}
+				if message.Content != deniedContent(deniedErr) || !rec.Ephemeral() {
+					t.Errorf("expected ephemeral denied message, got %+v", rec.Response())
+				}
+				if tt.reason == DenialReasonMissingEntitlement && len(message.Components) != 1 {
+					t.Errorf("expected premium buttons, got %+v", message.Components)
+				}
+
+			default:
+				if handledErr != nil {
+					t.Errorf("unexpected error: %v", handledErr)
+				}
+				if message.Content != "allowed" {
+					t.Errorf("expected handler to be called, got %+v", rec.Response())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// TextCheck checks whether a text command is allowed. It returns a *DeniedError to deny the text command.
+// Other errors are returned by the TextGuard middleware without replying.
+type TextCheck = EventCheck[*handler.TextCommandEvent]
+
+// TextGuardResponseFunc responds to a text command which was denied by a TextCheck.
+type TextGuardResponseFunc = DeniedResponseFunc[*handler.TextCommandEvent]
+
+// DefaultTextGuardResponse replies with a message which explains the DeniedError.
+var DefaultTextGuardResponse TextGuardResponseFunc = func(event *handler.TextCommandEvent, err *DeniedError) error {
+	_, replyErr := event.Reply(discord.MessageCreate{
+		Content: deniedContent(err),
+	})
+	return replyErr
+}
+
+// TextGuard is the Guard middleware for text commands. It runs the given TextCheck(s) in order and denies the text command
+// on the first *DeniedError with the DefaultTextGuardResponse. The DeniedError is returned afterward, so it is reported to the handler.TextErrorHandler.
+func TextGuard(checks ...TextCheck) handler.TextMiddleware {
+	return TextGuardWith(DefaultTextGuardResponse, checks...)
+}
+
+// TextGuardWith is like TextGuard but responds to denied text commands with the given TextGuardResponseFunc.
+func TextGuardWith(response TextGuardResponseFunc, checks ...TextCheck) handler.TextMiddleware {
+	return func(next handler.TextHandler) handler.TextHandler {
+		return func(event *handler.TextCommandEvent) error {
+			if err := runChecks(event, response, checks); err != nil {
+				return err
+			}
+			return next(event)
+		}
+	}
+}
+
+// TextIsGuild is a TextCheck which denies text commands outside of guilds.
+func TextIsGuild(event *handler.TextCommandEvent) error {
+	if event.GuildID == nil {
+		return &DeniedError{Reason: DenialReasonGuildOnly}
+	}
+	return nil
+}
+
+// TextHasPermissions returns a TextCheck which denies text commands of users missing any of the given permissions in the channel.
+// The permissions are calculated from the cache. Text commands outside of guilds are denied with DenialReasonGuildOnly.
+func TextHasPermissions(permissions discord.Permissions) TextCheck {
+	return func(event *handler.TextCommandEvent) error {
+		if event.GuildID == nil {
+			return &DeniedError{Reason: DenialReasonGuildOnly}
+		}
+		memberPermissions := event.MemberPermissions()
+		if missing := permissions &^ memberPermissions; missing != 0 && !memberPermissions.Has(discord.PermissionAdministrator) {
+			return &DeniedError{Reason: DenialReasonMissingPermissions, Permissions: missing}
+		}
+		return nil
+	}
+}
+
+// TextBotHasPermissions returns a TextCheck which denies text commands if the bot is missing any of the given permissions in the channel.
+// The permissions are calculated from the cache. Text commands outside of guilds are always allowed.
+func TextBotHasPermissions(permissions discord.Permissions) TextCheck {
+	return func(event *handler.TextCommandEvent) error {
+		if event.GuildID == nil {
+			return nil
+		}
+		botPermissions := event.BotPermissions()
+		if missing := permissions &^ botPermissions; missing != 0 && !botPermissions.Has(discord.PermissionAdministrator) {
+			return &DeniedError{Reason: DenialReasonBotMissingPermissions, Permissions: missing}
+		}
+		return nil
+	}
+}
+
+// TextIsNSFWChannel is a TextCheck which denies text commands outside of age-restricted channels.
+// The channel and the parent channel of threads need to be cached.
+func TextIsNSFWChannel(event *handler.TextCommandEvent) error {
+	if channel, ok := event.Client().Caches.Channel(event.ChannelID); !ok || !isNSFWChannel(event.Client(), channel) {
+		return &DeniedError{Reason: DenialReasonNSFWOnly}
+	}
+	return nil
+}
+
+// TextIsApplicationOwner returns a TextCheck which denies text commands of users who are neither the owner nor an accepted team member of the application.
+// The owners are fetched once with the first text command and cached afterward.
+func TextIsApplicationOwner() TextCheck {
+	var owners applicationOwners
+	return func(event *handler.TextCommandEvent) error {
+		return owners.check(event.Client(), event.Message.Author.ID)
+	}
+}
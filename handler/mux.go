@@ -8,6 +8,7 @@ import (
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 )
 
 var defaultErrorHandler ErrorHandler = func(event *InteractionEvent, err error) {
@@ -35,6 +36,7 @@ type Mux struct {
 	notFoundHandler NotFoundHandler
 	errorHandler    ErrorHandler
 	defaultContext  func() context.Context
+	bundle          i18n.Bundle
 }
 
 // OnEvent is called when a new event is received.
@@ -43,7 +45,20 @@ func (r *Mux) OnEvent(event bot.Event) {
 	if !ok {
 		return
 	}
+	r.handleInteraction(e, r.errorHandler)
+}
 
+// HandleInteraction routes the interaction like OnEvent but returns the error of the handler instead of passing it to the ErrorHandler.
+// This is useful for testing, see the handlertest package.
+func (r *Mux) HandleInteraction(e *events.InteractionCreate) error {
+	var handlerErr error
+	r.handleInteraction(e, func(_ *InteractionEvent, err error) {
+		handlerErr = err
+	})
+	return handlerErr
+}
+
+func (r *Mux) handleInteraction(e *events.InteractionCreate, errorHandler ErrorHandler) {
 	var path string
 	switch i := e.Interaction.(type) {
 	case discord.ApplicationCommandInteraction:
@@ -76,9 +91,18 @@ func (r *Mux) OnEvent(event bot.Event) {
 		Ctx:               ctx,
 		Vars:              make(map[string]string),
 	}
+	if r.bundle != nil {
+		locales := []discord.Locale{e.Locale()}
+		if guildLocale := e.GuildLocale(); guildLocale != nil {
+			locales = append(locales, *guildLocale)
+		}
+		ie.Translator = r.bundle.Translator(locales...)
+	}
+	t, t2 := routeTypes(e.Interaction)
+	ie.Pattern = r.routePattern(path, t, t2)
 	if err := r.Handle(path, ie); err != nil {
-		if r.errorHandler != nil {
-			r.errorHandler(ie, err)
+		if errorHandler != nil {
+			errorHandler(ie, err)
 			return
 		}
 		defaultErrorHandler(ie, err)
@@ -113,20 +137,30 @@ func (r *Mux) Match(path string, t discord.InteractionType, t2 int) bool {
 	return false
 }
 
+// routePattern returns the full pattern of the route which matches the given path.
+func (r *Mux) routePattern(path string, t discord.InteractionType, t2 int) string {
+	path = parseVariables(path, r.pattern, make(map[string]string))
+	for _, route := range r.routes {
+		if !route.Match(path, t, t2) {
+			continue
+		}
+		switch rt := route.(type) {
+		case *Mux:
+			return r.pattern + rt.routePattern(path, t, t2)
+		case interface{ routePattern() string }:
+			return r.pattern + rt.routePattern()
+		}
+		return r.pattern
+	}
+	return r.pattern
+}
+
 // Handle handles the given interaction event.
 func (r *Mux) Handle(path string, event *InteractionEvent) error {
 	path = parseVariables(path, r.pattern, event.Vars)
 
 	handlerChain := Handler(func(event *InteractionEvent) error {
-		t := event.Type()
-		var t2 int
-		switch i := event.Interaction.(type) {
-		case discord.ApplicationCommandInteraction:
-			t2 = int(i.Data.Type())
-		case discord.ComponentInteraction:
-			t2 = int(i.Data.Type())
-		}
-
+		t, t2 := routeTypes(event.Interaction)
 		for _, route := range r.routes {
 			if route.Match(path, t, t2) {
 				return route.Handle(path, event)
@@ -325,6 +359,25 @@ func (r *Mux) DefaultContext(ctx func() context.Context) {
 	r.defaultContext = ctx
 }
 
+// Bundle sets the i18n.Bundle for this router.
+// It is used to create the Translator of all interaction events for the locale of the user with the locale of the guild as fallback.
+// This bundle only works for the root router and will be ignored for sub routers.
+func (r *Mux) Bundle(bundle i18n.Bundle) {
+	r.bundle = bundle
+}
+
+// routeTypes returns the interaction type and the command or component type of the interaction used to match routes.
+func routeTypes(interaction discord.Interaction) (discord.InteractionType, int) {
+	var t2 int
+	switch i := interaction.(type) {
+	case discord.ApplicationCommandInteraction:
+		t2 = int(i.Data.Type())
+	case discord.ComponentInteraction:
+		t2 = int(i.Data.Type())
+	}
+	return interaction.Type(), t2
+}
+
 func checkPattern(pattern string) {
 	if len(pattern) == 0 {
 		panic("pattern must not be empty")
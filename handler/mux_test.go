@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"maps"
 	"os"
 	"reflect"
@@ -27,6 +28,43 @@ func (i *InteractionResponseRecorder) Respond(responseType discord.InteractionRe
 	return nil
 }
 
+// testUserID is the id of the user in the interactions of testdata/component.
+const testUserID = "53908232506183680"
+
+// testInteraction reads the interaction of the testdata file and replaces its custom id "/foo" and the user id.
+func testInteraction(t *testing.T, file string, customID string, userID string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file, err)
+	}
+	data = bytes.ReplaceAll(data, []byte(`"/foo"`), []byte(`"`+customID+`"`))
+	return bytes.ReplaceAll(data, []byte(`"`+testUserID+`"`), []byte(`"`+userID+`"`))
+}
+
+// dispatch dispatches the interaction to the mux and returns the recorded response.
+func dispatch(t *testing.T, mux *Mux, data []byte) *discord.InteractionResponse {
+	t.Helper()
+	interaction, err := discord.UnmarshalInteraction(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal interaction: %v", err)
+	}
+
+	recorder := NewRecorder()
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond:      recorder.Respond,
+	})
+	return recorder.Response
+}
+
+// clickButton dispatches the button interaction of testdata/component/button_component.json with the given custom id and user id to the mux.
+func clickButton(t *testing.T, mux *Mux, customID string, userID string) *discord.InteractionResponse {
+	t.Helper()
+	return dispatch(t, mux, testInteraction(t, "testdata/component/button_component.json", customID, userID))
+}
+
 func TestCommandMux(t *testing.T) {
 	slashData, err := os.ReadFile("testdata/command/slash_command.json")
 	if err != nil {
@@ -311,3 +349,27 @@ func TestMux(t *testing.T) {
 		}
 	}
 }
+
+func TestRoutePattern(t *testing.T) {
+	mux := New()
+	mux.Route("/mod", func(r Router) {
+		r.SlashCommand("/ban", func(data discord.SlashCommandInteractionData, e *CommandEvent) error { return nil })
+	})
+	mux.ButtonComponent("/page/{id}", func(data discord.ButtonInteractionData, e *ComponentEvent) error { return nil })
+
+	data := []struct {
+		path     string
+		t        discord.InteractionType
+		t2       int
+		expected string
+	}{
+		{path: "/mod/ban", t: discord.InteractionTypeApplicationCommand, t2: int(discord.ApplicationCommandTypeSlash), expected: "/mod/ban"},
+		{path: "/page/2", t: discord.InteractionTypeComponent, t2: int(discord.ComponentTypeButton), expected: "/page/{id}"},
+		{path: "/unknown", t: discord.InteractionTypeComponent, t2: int(discord.ComponentTypeButton), expected: ""},
+	}
+	for _, d := range data {
+		if pattern := mux.routePattern(d.path, d.t, d.t2); pattern != d.expected {
+			t.Errorf("expected pattern %q for %s, got %q", d.expected, d.path, pattern)
+		}
+	}
+}
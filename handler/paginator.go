@@ -0,0 +1,402 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	// paginatorCheckInterval is the interval in which expired paginations are disabled.
+	paginatorCheckInterval = 10 * time.Second
+	// maxPaginatorTimeout is the maximum timeout of a pagination, so the buttons are disabled before the interaction token expires after 15 minutes.
+	maxPaginatorTimeout = 15*time.Minute - 2*paginatorCheckInterval
+)
+
+// ErrNoPages is returned by Paginator.Create if the PageProvider has no pages.
+var ErrNoPages = errors.New("paginator has no pages")
+
+var (
+	_ PageProvider = (staticPages)(nil)
+	_ PageProvider = (*lazyPages)(nil)
+)
+
+// Page is a single page of a Paginator.
+type Page struct {
+	// Embed is the embed of the page. It is used unless WithPaginatorComponentsV2 is set.
+	Embed discord.Embed
+	// Components are the components of the container of the page. They are used if WithPaginatorComponentsV2 is set.
+	Components []discord.ContainerSubComponent
+}
+
+// PageProvider provides the pages of a Paginator.
+type PageProvider interface {
+	// PageCount returns the number of pages.
+	PageCount(ctx context.Context) (int, error)
+
+	// Page returns the page at the given index, starting at 0.
+	Page(ctx context.Context, index int) (Page, error)
+}
+
+// StaticPages returns a PageProvider for the given pages.
+func StaticPages(pages ...Page) PageProvider {
+	return staticPages(pages)
+}
+
+type staticPages []Page
+
+func (p staticPages) PageCount(_ context.Context) (int, error) {
+	return len(p), nil
+}
+
+func (p staticPages) Page(_ context.Context, index int) (Page, error) {
+	return p[index], nil
+}
+
+// LazyPages returns a PageProvider with count pages which are only built when they are shown, e.g. by querying a database.
+func LazyPages(count int, page func(ctx context.Context, index int) (Page, error)) PageProvider {
+	return &lazyPages{
+		count: count,
+		page:  page,
+	}
+}
+
+type lazyPages struct {
+	count int
+	page  func(ctx context.Context, index int) (Page, error)
+}
+
+func (p *lazyPages) PageCount(_ context.Context) (int, error) {
+	return p.count, nil
+}
+
+func (p *lazyPages) Page(ctx context.Context, index int) (Page, error) {
+	return p.page(ctx, index)
+}
+
+// PaginatorEvent is an interaction event which can be responded to with a paginator, e.g. *CommandEvent, *ComponentEvent or *ModalEvent.
+type PaginatorEvent interface {
+	CreateMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) error
+	ApplicationID() snowflake.ID
+	Token() string
+	User() discord.User
+	Client() *bot.Client
+}
+
+// NewPaginator returns a new Paginator with the PaginatorConfigOpt(s) applied.
+func NewPaginator(opts ...PaginatorConfigOpt) *Paginator {
+	cfg := defaultPaginatorConfig()
+	cfg.apply(opts)
+	return &Paginator{
+		config: cfg,
+		// the state is kept longer than the timeout, so it is still available when the buttons are disabled
+		state:       NewState[paginationState](WithStateStore(cfg.Store), WithStateTTL(cfg.Timeout+2*paginatorCheckInterval)),
+		paginations: make(map[string]*pagination),
+		done:        make(chan struct{}),
+	}
+}
+
+// Paginator responds with messages which have multiple pages. The pages can be navigated with first, previous, next and last buttons,
+// and the page indicator button opens a modal to jump to a page. Only the user who created the pagination can navigate it.
+//
+// The navigation state of the paginations is stored in the StateStore, see WithPaginatorStateStore. The PageProvider(s) can't be serialized
+// and are kept in memory, so paginations expire on restart. After the timeout without navigation, the buttons are disabled by a single
+// goroutine per Paginator, which is started with the first pagination and stopped with Paginator.Close.
+//
+//	paginator := handler.NewPaginator()
+//	paginator.Register(r)
+//	r.SlashCommand("/list", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+//		return paginator.Create(e.Ctx, e, handler.StaticPages(pages...), false)
+//	})
+type Paginator struct {
+	config      paginatorConfig
+	state       *State[paginationState]
+	mu          sync.Mutex
+	paginations map[string]*pagination
+	startOnce   sync.Once
+	closeOnce   sync.Once
+	done        chan struct{}
+}
+
+// pagination is the in-memory part of a pagination which can't be stored in the StateStore.
+type pagination struct {
+	client    *bot.Client
+	provider  PageProvider
+	expiresAt time.Time
+}
+
+// paginationState is the navigation state of a pagination which is stored in the StateStore.
+type paginationState struct {
+	UserID        snowflake.ID `json:"user_id"`
+	ApplicationID snowflake.ID `json:"application_id"`
+	Token         string       `json:"token"`
+	Index         int          `json:"index"`
+	Count         int          `json:"count"`
+}
+
+// Register registers the handlers of the navigation buttons and the jump modal on the Router.
+func (p *Paginator) Register(r Router) {
+	r.Component(p.config.Prefix+"/{paginator}/{action}", p.handleComponent)
+	r.Modal(p.config.Prefix+"/{paginator}/jump", p.handleJump)
+}
+
+// Create responds to the interaction with the first page of the PageProvider.
+func (p *Paginator) Create(ctx context.Context, e PaginatorEvent, provider PageProvider, ephemeral bool) error {
+	count, err := provider.PageCount(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get page count: %w", err)
+	}
+	if count < 1 {
+		return ErrNoPages
+	}
+	page, err := provider.Page(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get page 0: %w", err)
+	}
+	id, err := p.state.Save(ctx, paginationState{
+		UserID:        e.User().ID,
+		ApplicationID: e.ApplicationID(),
+		Token:         e.Token(),
+		Count:         count,
+	})
+	if err != nil {
+		return err
+	}
+
+	messageCreate := discord.MessageCreate{
+		Components: p.components(id, 0, count, page, false),
+	}
+	if ephemeral {
+		messageCreate.Flags = messageCreate.Flags.Add(discord.MessageFlagEphemeral)
+	}
+	if p.config.ComponentsV2 {
+		messageCreate.Flags = messageCreate.Flags.Add(discord.MessageFlagIsComponentsV2)
+	} else {
+		messageCreate.Embeds = []discord.Embed{page.Embed}
+	}
+
+	p.mu.Lock()
+	p.paginations[id] = &pagination{
+		client:    e.Client(),
+		provider:  provider,
+		expiresAt: time.Now().Add(p.config.Timeout),
+	}
+	p.mu.Unlock()
+	p.startOnce.Do(func() {
+		go p.expireLoop()
+	})
+
+	if err = e.CreateMessage(messageCreate); err != nil {
+		p.mu.Lock()
+		delete(p.paginations, id)
+		p.mu.Unlock()
+		return errors.Join(err, p.state.Delete(ctx, id))
+	}
+	return nil
+}
+
+// Close stops disabling the buttons of expired paginations.
+func (p *Paginator) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *Paginator) handleComponent(e *ComponentEvent) error {
+	id := e.Vars["paginator"]
+	pg, state, ok, err := p.get(e.Ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.CreateMessage(p.config.ExpiredMessage)
+	}
+	if e.User().ID != state.UserID {
+		return e.CreateMessage(p.config.NotAllowedMessage)
+	}
+
+	index := state.Index
+	switch e.Vars["action"] {
+	case "first":
+		index = 0
+	case "previous":
+		index--
+	case "next":
+		index++
+	case "last":
+		index = state.Count - 1
+	case "jump":
+		return e.Modal(p.jumpModal(id, state.Count))
+	}
+	return p.show(e.Ctx, id, pg, state, max(0, min(index, state.Count-1)), e.Token(), e.UpdateMessage)
+}
+
+func (p *Paginator) handleJump(e *ModalEvent) error {
+	id := e.Vars["paginator"]
+	pg, state, ok, err := p.get(e.Ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.CreateMessage(p.config.ExpiredMessage)
+	}
+	if e.User().ID != state.UserID {
+		return e.CreateMessage(p.config.NotAllowedMessage)
+	}
+
+	page, err := strconv.Atoi(strings.TrimSpace(e.Data.Text("page")))
+	if err != nil || page < 1 || page > state.Count {
+		return e.CreateMessage(p.config.InvalidPageMessage)
+	}
+	return p.show(e.Ctx, id, pg, state, page-1, e.Token(), e.UpdateMessage)
+}
+
+// get returns a copy of the pagination and its state if it exists and did not expire yet.
+func (p *Paginator) get(ctx context.Context, id string) (pagination, paginationState, bool, error) {
+	p.mu.Lock()
+	pg, ok := p.paginations[id]
+	if !ok || !pg.expiresAt.After(time.Now()) {
+		p.mu.Unlock()
+		return pagination{}, paginationState{}, false, nil
+	}
+	current := *pg
+	p.mu.Unlock()
+
+	state, ok, err := p.state.Load(ctx, id)
+	if err != nil || !ok {
+		return pagination{}, paginationState{}, false, err
+	}
+	return current, state, true, nil
+}
+
+// show updates the message to the page at the given index and extends the timeout of the pagination.
+func (p *Paginator) show(ctx context.Context, id string, pg pagination, state paginationState, index int, token string, updateMessage func(messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) error) error {
+	page, err := pg.provider.Page(ctx, index)
+	if err != nil {
+		return fmt.Errorf("failed to get page %d: %w", index, err)
+	}
+
+	components := p.components(id, index, state.Count, page, false)
+	messageUpdate := discord.MessageUpdate{
+		Components: &components,
+	}
+	if !p.config.ComponentsV2 {
+		messageUpdate.Embeds = &[]discord.Embed{page.Embed}
+	}
+	if err = updateMessage(messageUpdate); err != nil {
+		return err
+	}
+
+	state.Index = index
+	// the token of the latest interaction is used to disable the buttons, as it is only valid for 15 minutes
+	state.Token = token
+	if err = p.state.Update(ctx, id, state); errors.Is(err, ErrStateNotFound) {
+		// the pagination expired in the meantime
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, ok := p.paginations[id]; ok {
+		current.expiresAt = time.Now().Add(p.config.Timeout)
+	}
+	return nil
+}
+
+func (p *Paginator) components(id string, index int, count int, page Page, disabled bool) []discord.LayoutComponent {
+	customID := p.config.Prefix + "/" + id + "/"
+	navigation := discord.NewActionRow(
+		discord.NewSecondaryButton(p.config.Labels[0], customID+"first").WithDisabled(disabled || index == 0),
+		discord.NewSecondaryButton(p.config.Labels[1], customID+"previous").WithDisabled(disabled || index == 0),
+		discord.NewPrimaryButton(fmt.Sprintf("%d/%d", index+1, count), customID+"jump").WithDisabled(disabled || count == 1),
+		discord.NewSecondaryButton(p.config.Labels[2], customID+"next").WithDisabled(disabled || index == count-1),
+		discord.NewSecondaryButton(p.config.Labels[3], customID+"last").WithDisabled(disabled || index == count-1),
+	)
+	if !p.config.ComponentsV2 {
+		return []discord.LayoutComponent{navigation}
+	}
+	return []discord.LayoutComponent{discord.NewContainer(page.Components...), navigation}
+}
+
+func (p *Paginator) jumpModal(id string, count int) discord.ModalCreate {
+	return discord.NewModalCreate(p.config.Prefix+"/"+id+"/jump", "Jump to page", []discord.LayoutComponent{
+		discord.NewLabel("Page", discord.NewShortTextInput("page").
+			WithPlaceholder(fmt.Sprintf("1-%d", count)).
+			WithRequired(true),
+		),
+	})
+}
+
+func (p *Paginator) expireLoop() {
+	ticker := time.NewTicker(paginatorCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case now := <-ticker.C:
+			p.expire(now)
+		}
+	}
+}
+
+// expire removes all expired paginations and disables their buttons.
+func (p *Paginator) expire(now time.Time) {
+	expired := make(map[string]*pagination)
+	p.mu.Lock()
+	for id, pg := range p.paginations {
+		if !pg.expiresAt.After(now) {
+			expired[id] = pg
+			delete(p.paginations, id)
+		}
+	}
+	p.mu.Unlock()
+
+	for id, pg := range expired {
+		if err := p.disable(id, pg); err != nil {
+			p.config.Logger.Error("failed to disable paginator", slog.String("paginator", id), slog.Any("err", err))
+		}
+	}
+}
+
+// disable disables the buttons of the pagination and deletes its state.
+func (p *Paginator) disable(id string, pg *pagination) error {
+	ctx, cancel := context.WithTimeout(context.Background(), paginatorCheckInterval)
+	defer cancel()
+
+	state, ok, err := p.state.Load(ctx, id)
+	if err != nil || !ok {
+		return err
+	}
+	if err = p.state.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	var page Page
+	if p.config.ComponentsV2 {
+		if page, err = pg.provider.Page(ctx, state.Index); err != nil {
+			return fmt.Errorf("failed to get page %d: %w", state.Index, err)
+		}
+	}
+	if pg.client == nil {
+		return errors.New("pagination has no client")
+	}
+	components := p.components(id, state.Index, state.Count, page, true)
+	_, err = pg.client.Rest.UpdateInteractionResponse(state.ApplicationID, state.Token, discord.MessageUpdate{
+		Components: &components,
+	}, rest.WithCtx(ctx))
+	return err
+}
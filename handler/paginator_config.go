@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultPaginatorConfig() paginatorConfig {
+	return paginatorConfig{
+		Logger:  slog.Default(),
+		Prefix:  "/paginator",
+		Timeout: 5 * time.Minute,
+		Labels:  [4]string{"⏮", "◀", "▶", "⏭"},
+		NotAllowedMessage: discord.MessageCreate{
+			Content: "You can't use this paginator.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+		ExpiredMessage: discord.MessageCreate{
+			Content: "This paginator has expired. Please run the command again.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+		InvalidPageMessage: discord.MessageCreate{
+			Content: "Please enter a valid page number.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+	}
+}
+
+type paginatorConfig struct {
+	Logger             *slog.Logger
+	Prefix             string
+	Timeout            time.Duration
+	Store              StateStore
+	ComponentsV2       bool
+	Labels             [4]string
+	NotAllowedMessage  discord.MessageCreate
+	ExpiredMessage     discord.MessageCreate
+	InvalidPageMessage discord.MessageCreate
+}
+
+// PaginatorConfigOpt is a functional option for configuring a Paginator.
+type PaginatorConfigOpt func(config *paginatorConfig)
+
+func (c *paginatorConfig) apply(opts []PaginatorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryStateStore()
+	}
+	c.Logger = c.Logger.With(slog.String("name", "handler_paginator"))
+}
+
+// WithPaginatorLogger overrides the default Logger which logs errors while disabling expired paginations.
+func WithPaginatorLogger(logger *slog.Logger) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithPaginatorPrefix sets the custom ID prefix of the navigation buttons and the jump modal. Defaults to "/paginator".
+func WithPaginatorPrefix(prefix string) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.Prefix = prefix
+	}
+}
+
+// WithPaginatorTimeout sets after which time without interaction the navigation buttons are disabled. Defaults to 5 minutes.
+// The buttons are disabled with the token of the last interaction, which expires after 15 minutes, so longer timeouts are clamped to 14 minutes and 40 seconds.
+func WithPaginatorTimeout(timeout time.Duration) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.Timeout = min(timeout, maxPaginatorTimeout)
+	}
+}
+
+// WithPaginatorStateStore overrides the in-memory StateStore in which the navigation state of the paginations is stored.
+func WithPaginatorStateStore(store StateStore) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.Store = store
+	}
+}
+
+// WithPaginatorComponentsV2 renders the pages as Components V2 containers with Page.Components instead of embeds with Page.Embed.
+func WithPaginatorComponentsV2() PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.ComponentsV2 = true
+	}
+}
+
+// WithPaginatorButtonLabels overrides the labels of the first, previous, next and last buttons.
+func WithPaginatorButtonLabels(first string, previous string, next string, last string) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.Labels = [4]string{first, previous, next, last}
+	}
+}
+
+// WithPaginatorNotAllowedMessage overrides the message which is sent when another user than the invoking user uses the paginator.
+func WithPaginatorNotAllowedMessage(message discord.MessageCreate) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.NotAllowedMessage = message
+	}
+}
+
+// WithPaginatorExpiredMessage overrides the message which is sent when the paginator is no longer known, e.g. after a restart.
+func WithPaginatorExpiredMessage(message discord.MessageCreate) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.ExpiredMessage = message
+	}
+}
+
+// WithPaginatorInvalidPageMessage overrides the message which is sent when an invalid page number is entered in the jump modal.
+func WithPaginatorInvalidPageMessage(message discord.MessageCreate) PaginatorConfigOpt {
+	return func(config *paginatorConfig) {
+		config.InvalidPageMessage = message
+	}
+}
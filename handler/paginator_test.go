@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestPaginator(t *testing.T) {
+	store := NewMemoryStateStore()
+	paginator := NewPaginator(WithPaginatorStateStore(store))
+	defer paginator.Close()
+
+	mux := New()
+	paginator.Register(mux)
+	mux.Component("/foo", func(e *ComponentEvent) error {
+		return paginator.Create(e.Ctx, e, StaticPages(
+			Page{Embed: discord.Embed{Title: "1"}},
+			Page{Embed: discord.Embed{Title: "2"}},
+			Page{Embed: discord.Embed{Title: "3"}},
+		), false)
+	})
+
+	response := clickButton(t, mux, "/foo", testUserID)
+	messageCreate, ok := response.Data.(discord.MessageCreate)
+	if !ok || len(messageCreate.Embeds) != 1 || messageCreate.Embeds[0].Title != "1" {
+		t.Fatalf("expected first page, got %+v", response)
+	}
+	buttons := messageCreate.Components[0].(discord.ActionRowComponent).Components
+	if !buttons[0].(discord.ButtonComponent).Disabled || buttons[3].(discord.ButtonComponent).Disabled {
+		t.Errorf("expected only first and previous buttons to be disabled, got %+v", buttons)
+	}
+	next := buttons[3].(discord.ButtonComponent).CustomID
+
+	response = clickButton(t, mux, next, "1")
+	if !reflect.DeepEqual(response.Data, paginator.config.NotAllowedMessage) {
+		t.Errorf("expected not allowed message, got %+v", response)
+	}
+
+	response = clickButton(t, mux, next, testUserID)
+	messageUpdate, ok := response.Data.(discord.MessageUpdate)
+	if response.Type != discord.InteractionResponseTypeUpdateMessage || !ok || (*messageUpdate.Embeds)[0].Title != "2" {
+		t.Errorf("expected second page, got %+v", response)
+	}
+
+	id := strings.Split(next, "/")[2]
+	var state paginationState
+	if data, ok, err := store.Get(context.Background(), id); err != nil || !ok {
+		t.Fatalf("expected pagination state in the store, got %t, %v", ok, err)
+	} else if err = json.Unmarshal(data, &state); err != nil || state.Index != 1 {
+		t.Errorf("expected stored index 1, got %+v, %v", state, err)
+	}
+
+	if err := store.Delete(context.Background(), id); err != nil {
+		t.Fatalf("failed to delete state: %v", err)
+	}
+	response = clickButton(t, mux, next, testUserID)
+	if !reflect.DeepEqual(response.Data, paginator.config.ExpiredMessage) {
+		t.Errorf("expected expired message after the state was deleted, got %+v", response)
+	}
+
+	response = clickButton(t, mux, "/paginator/unknown/next", testUserID)
+	if !reflect.DeepEqual(response.Data, paginator.config.ExpiredMessage) {
+		t.Errorf("expected expired message, got %+v", response)
+	}
+}
+
+func TestPaginatorTimeout(t *testing.T) {
+	if timeout := NewPaginator(WithPaginatorTimeout(time.Hour)).config.Timeout; timeout != maxPaginatorTimeout {
+		t.Errorf("expected timeout to be clamped to %s, got %s", maxPaginatorTimeout, timeout)
+	}
+	if timeout := NewPaginator(WithPaginatorTimeout(time.Minute)).config.Timeout; timeout != time.Minute {
+		t.Errorf("expected timeout of 1m, got %s", timeout)
+	}
+}
+
+func TestPaginatorExpire(t *testing.T) {
+	paginator := NewPaginator(WithPaginatorLogger(slog.New(slog.DiscardHandler)))
+	defer paginator.Close()
+
+	restClient := &testRestClient{}
+	pages := StaticPages(Page{Embed: discord.Embed{Title: "1"}}, Page{Embed: discord.Embed{Title: "2"}})
+	save := func(client *bot.Client, expiresAt time.Time) string {
+		id, err := paginator.state.Save(context.Background(), paginationState{ApplicationID: 10, Token: "token", Count: 2})
+		if err != nil {
+			t.Fatalf("failed to save state: %v", err)
+		}
+		paginator.paginations[id] = &pagination{client: client, provider: pages, expiresAt: expiresAt}
+		return id
+	}
+	now := time.Now()
+	expired := save(&bot.Client{Rest: rest.New(restClient)}, now.Add(-time.Second))
+	active := save(&bot.Client{Rest: rest.New(restClient)}, now.Add(time.Minute))
+	save(nil, now.Add(-time.Second))
+
+	paginator.expire(now)
+
+	if _, ok := paginator.paginations[active]; !ok || len(paginator.paginations) != 1 {
+		t.Errorf("expected only the active pagination to be kept, got %+v", paginator.paginations)
+	}
+	if _, ok, _ := paginator.state.Load(context.Background(), expired); ok {
+		t.Errorf("expected the state of the expired pagination to be deleted")
+	}
+
+	if len(restClient.endpoints) != 1 || restClient.endpoints[0].URL != rest.UpdateInteractionResponse.Compile(nil, 10, "token").URL {
+		t.Fatalf("expected the interaction response to be updated, got %+v", restClient.endpoints)
+	}
+	messageUpdate, ok := restClient.bodies[0].(discord.MessageUpdate)
+	if !ok || messageUpdate.Components == nil {
+		t.Fatalf("expected components to be updated, got %+v", restClient.bodies[0])
+	}
+	for _, button := range (*messageUpdate.Components)[0].(discord.ActionRowComponent).Components {
+		if !button.(discord.ButtonComponent).Disabled {
+			t.Errorf("expected all buttons to be disabled, got %+v", button)
+		}
+	}
+}
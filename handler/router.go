@@ -36,8 +36,12 @@ type (
 	// NotFoundHandler is a function that is called when no route was found.
 	NotFoundHandler func(e *InteractionEvent) error
 
+	// EventErrorHandler is a function that is called when an error occurs during handling an event of type E.
+	// It allows to share an error handler between the Mux and the TextMux.
+	EventErrorHandler[E any] func(e E, err error)
+
 	// ErrorHandler is a function that is called when an error occurs during handling an interaction.
-	ErrorHandler func(e *InteractionEvent, err error)
+	ErrorHandler = EventErrorHandler[*InteractionEvent]
 )
 
 var (
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// ErrStateNotFound is returned by State.Update if the token is unknown or its state expired.
+var ErrStateNotFound = errors.New("state not found")
+
+type (
+	// StateComponentHandler is a function that handles component interactions with the state restored from the token in the custom ID.
+	StateComponentHandler[T any] func(state T, e *ComponentEvent) error
+	// StateModalHandler is a function that handles modals with the state restored from the token in the custom ID.
+	StateModalHandler[T any] func(state T, e *ModalEvent) error
+)
+
+// NewState returns a new State with the StateConfigOpt(s) applied.
+func NewState[T any](opts ...StateConfigOpt) *State[T] {
+	cfg := defaultStateConfig()
+	cfg.apply(opts)
+	return &State[T]{config: cfg}
+}
+
+// State stores state of type T server-side and returns a short token for custom IDs, which are limited to 100 characters.
+// The state is serialized as JSON, so T needs to be JSON serializable.
+//
+//	pages := handler.NewState[PageState]()
+//	customID, err := pages.CustomID(e.Ctx, "/page/", PageState{Cursor: cursor, Filter: filter})
+//	r.Component("/page/{state}", pages.Component(func(state PageState, e *handler.ComponentEvent) error { ... }))
+type State[T any] struct {
+	config stateConfig
+}
+
+// Save stores the state and returns its token.
+func (s *State[T]) Save(ctx context.Context, state T) (string, error) {
+	token, err := newStateToken()
+	if err != nil {
+		return "", err
+	}
+	if err = s.put(ctx, token, state); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Update replaces the state of an existing token and resets its TTL.
+// It returns ErrStateNotFound if the token is unknown or its state expired.
+func (s *State[T]) Update(ctx context.Context, token string, state T) error {
+	if _, ok, err := s.config.Store.Get(ctx, token); err != nil {
+		return err
+	} else if !ok {
+		return ErrStateNotFound
+	}
+	return s.put(ctx, token, state)
+}
+
+func (s *State[T]) put(ctx context.Context, token string, state T) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return s.config.Store.Put(ctx, token, data, s.config.TTL)
+}
+
+// CustomID stores the state and returns the prefix with the token appended, e.g. "/page/" + token.
+func (s *State[T]) CustomID(ctx context.Context, prefix string, state T) (string, error) {
+	token, err := s.Save(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	return prefix + token, nil
+}
+
+// Load returns the state of the token and whether it was found.
+func (s *State[T]) Load(ctx context.Context, token string) (T, bool, error) {
+	var state T
+	data, ok, err := s.config.Store.Get(ctx, token)
+	if err != nil || !ok {
+		return state, false, err
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return state, false, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return state, true, nil
+}
+
+// Delete deletes the state of the token, e.g. when the component is not used anymore.
+func (s *State[T]) Delete(ctx context.Context, token string) error {
+	return s.config.Store.Delete(ctx, token)
+}
+
+// Component returns a ComponentHandler which restores the state from the token in the path variable before calling h.
+// If the state expired, the expired message is sent instead.
+func (s *State[T]) Component(h StateComponentHandler[T]) ComponentHandler {
+	return func(e *ComponentEvent) error {
+		state, ok, err := s.Load(e.Ctx, e.Vars[s.config.Var])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return e.CreateMessage(s.config.ExpiredMessage)
+		}
+		return h(state, e)
+	}
+}
+
+// Modal returns a ModalHandler which restores the state from the token in the path variable before calling h.
+// If the state expired, the expired message is sent instead.
+func (s *State[T]) Modal(h StateModalHandler[T]) ModalHandler {
+	return func(e *ModalEvent) error {
+		state, ok, err := s.Load(e.Ctx, e.Vars[s.config.Var])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return e.CreateMessage(s.config.ExpiredMessage)
+		}
+		return h(state, e)
+	}
+}
+
+// newStateToken returns a random URL safe token with 16 characters.
+func newStateToken() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate state token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultStateConfig() stateConfig {
+	return stateConfig{
+		TTL: 15 * time.Minute,
+		Var: "state",
+		ExpiredMessage: discord.MessageCreate{
+			Content: "This interaction has expired. Please run the command again.",
+			Flags:   discord.MessageFlagEphemeral,
+		},
+	}
+}
+
+type stateConfig struct {
+	TTL            time.Duration
+	Var            string
+	Store          StateStore
+	ExpiredMessage discord.MessageCreate
+}
+
+// StateConfigOpt is a functional option for configuring a State.
+type StateConfigOpt func(config *stateConfig)
+
+func (c *stateConfig) apply(opts []StateConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryStateStore()
+	}
+}
+
+// WithStateTTL sets how long the state is stored. Defaults to 15 minutes.
+func WithStateTTL(ttl time.Duration) StateConfigOpt {
+	return func(config *stateConfig) {
+		config.TTL = ttl
+	}
+}
+
+// WithStateVar sets the name of the path variable which contains the token. Defaults to "state".
+func WithStateVar(name string) StateConfigOpt {
+	return func(config *stateConfig) {
+		config.Var = name
+	}
+}
+
+// WithStateStore overrides the in-memory StateStore. Multiple State(s) can share the same StateStore.
+func WithStateStore(store StateStore) StateConfigOpt {
+	return func(config *stateConfig) {
+		config.Store = store
+	}
+}
+
+// WithStateExpiredMessage overrides the message which is sent when the state of a token expired.
+func WithStateExpiredMessage(message discord.MessageCreate) StateConfigOpt {
+	return func(config *stateConfig) {
+		config.ExpiredMessage = message
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ StateStore = (*memoryStateStore)(nil)
+
+// StateStore stores the serialized state of components by token. Implement it to share state between processes or restarts, e.g. with Redis.
+type StateStore interface {
+	// Put stores the data with the given token until the ttl expires.
+	Put(ctx context.Context, token string, data []byte, ttl time.Duration) error
+
+	// Get returns the data of the given token and whether it was found. Expired data must not be returned.
+	Get(ctx context.Context, token string) ([]byte, bool, error)
+
+	// Delete deletes the data of the given token.
+	Delete(ctx context.Context, token string) error
+}
+
+// NewMemoryStateStore returns a new in-memory StateStore. Expired state is removed lazily.
+func NewMemoryStateStore() StateStore {
+	return &memoryStateStore{
+		states: make(map[string]memoryState),
+	}
+}
+
+type memoryState struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+type memoryStateStore struct {
+	mu          sync.Mutex
+	states      map[string]memoryState
+	lastCleanup time.Time
+}
+
+func (s *memoryStateStore) Put(_ context.Context, token string, data []byte, ttl time.Duration) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cleanup(now)
+	s.states[token] = memoryState{
+		data:      data,
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+func (s *memoryStateStore) Get(_ context.Context, token string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[token]
+	if !ok || !state.expiresAt.After(time.Now()) {
+		return nil, false, nil
+	}
+	return state.data, true, nil
+}
+
+func (s *memoryStateStore) Delete(_ context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, token)
+	return nil
+}
+
+// cleanup removes all expired states at most once per minute.
+func (s *memoryStateStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < time.Minute {
+		return
+	}
+	s.lastCleanup = now
+	for token, state := range s.states {
+		if !state.expiresAt.After(now) {
+			delete(s.states, token)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type pageState struct {
+	Cursor string `json:"cursor"`
+	Page   int    `json:"page"`
+}
+
+func TestStateComponent(t *testing.T) {
+	pages := NewState[pageState]()
+	customID, err := pages.CustomID(context.Background(), "/page/", pageState{Cursor: "abc", Page: 2})
+	if err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	mux := New()
+	mux.Component("/page/{state}", pages.Component(func(state pageState, e *ComponentEvent) error {
+		if state.Cursor != "abc" || state.Page != 2 {
+			t.Errorf("unexpected state: %+v", state)
+		}
+		return e.CreateMessage(discord.MessageCreate{Content: "bar"})
+	}))
+
+	data := []struct {
+		customID string
+		expected *discord.InteractionResponse
+	}{
+		{
+			customID: customID,
+			expected: &discord.InteractionResponse{
+				Type: discord.InteractionResponseTypeCreateMessage,
+				Data: discord.MessageCreate{Content: "bar"},
+			},
+		},
+		{
+			customID: "/page/expired",
+			expected: &discord.InteractionResponse{
+				Type: discord.InteractionResponseTypeCreateMessage,
+				Data: defaultStateConfig().ExpiredMessage,
+			},
+		},
+	}
+
+	for _, d := range data {
+		if response := clickButton(t, mux, d.customID, testUserID); !reflect.DeepEqual(d.expected, response) {
+			t.Errorf("expected %+v, got %+v", d.expected, response)
+		}
+	}
+}
+
+func TestStateUpdate(t *testing.T) {
+	pages := NewState[pageState]()
+	token, err := pages.Save(context.Background(), pageState{Page: 1})
+	if err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	if err = pages.Update(context.Background(), token, pageState{Page: 2}); err != nil {
+		t.Fatalf("failed to update state: %v", err)
+	}
+	if state, ok, err := pages.Load(context.Background(), token); err != nil || !ok || state.Page != 2 {
+		t.Errorf("expected updated state, got %+v, %t, %v", state, ok, err)
+	}
+
+	if err = pages.Update(context.Background(), "unknown", pageState{Page: 2}); !errors.Is(err, ErrStateNotFound) {
+		t.Errorf("expected ErrStateNotFound for unknown token, got %v", err)
+	}
+	if _, ok, _ := pages.Load(context.Background(), "unknown"); ok {
+		t.Errorf("expected unknown token to not be created by Update")
+	}
+}
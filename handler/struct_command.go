@@ -0,0 +1,789 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// StructSlashCommandHandler is a function that handles slash command interactions with the options decoded into T.
+type StructSlashCommandHandler[T any] func(cmd T, e *CommandEvent) error
+
+// ValidationError is returned by DecodeSlashCommand when an option does not satisfy the constraints of its struct field
+// or when the Validate method of the struct returns an error.
+type ValidationError struct {
+	// Option is the name of the invalid option or empty if the error was returned by a Validate method.
+	Option string
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Option == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("invalid option `%s`: %s", e.Option, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// StructSlashCommand registers a SlashCommandHandler to the Router which decodes the options into T with DecodeSlashCommand before calling h.
+// A ValidationError is sent back to the user as an ephemeral message, other errors are returned to the ErrorHandler.
+//
+// When T has subcommands, register it with the pattern of the command (e.g. "/mod") and check which subcommand field is set.
+func StructSlashCommand[T any](r Router, pattern string, h StructSlashCommandHandler[T]) {
+	r.SlashCommand(pattern, BindSlashCommand(h))
+}
+
+// BindSlashCommand returns a SlashCommandHandler which decodes the options into T with DecodeSlashCommand before calling h.
+// A ValidationError is sent back to the user as an ephemeral message, other errors are returned.
+func BindSlashCommand[T any](h StructSlashCommandHandler[T]) SlashCommandHandler {
+	return func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		cmd, err := DecodeSlashCommand[T](data)
+		if err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return e.CreateMessage(discord.MessageCreate{
+					Content: validationErr.Error(),
+					Flags:   discord.MessageFlagEphemeral,
+				})
+			}
+			return err
+		}
+		return h(cmd, e)
+	}
+}
+
+// SlashCommandFromStruct returns a discord.SlashCommandCreate with the given name and description and the options defined by the fields of T.
+//
+// Each exported field of T is an option. Non-pointer fields are required, pointer fields are optional. The following field types are supported:
+//   - string: discord.ApplicationCommandOptionString
+//   - int, int8, int16, int32, int64: discord.ApplicationCommandOptionInt
+//   - float32, float64: discord.ApplicationCommandOptionFloat
+//   - bool: discord.ApplicationCommandOptionBool
+//   - discord.User, discord.ResolvedMember: discord.ApplicationCommandOptionUser
+//   - discord.ResolvedChannel: discord.ApplicationCommandOptionChannel
+//   - discord.Role: discord.ApplicationCommandOptionRole
+//   - discord.MentionableValue, snowflake.ID: discord.ApplicationCommandOptionMentionable
+//   - discord.Attachment: discord.ApplicationCommandOptionAttachment
+//
+// A pointer to a struct is a subcommand. If the struct of a subcommand has subcommands itself, it is a subcommand group.
+// Subcommands can't be mixed with other options. Embedded structs are flattened.
+//
+// The options are configured with the following struct tags:
+//   - name: the name of the option, defaults to the field name in snake_case. "-" skips the field
+//   - description: the description of the option, required
+//   - required: "true" or "false" to override whether the option is required
+//   - min, max: the min and max value of int and float options
+//   - min_length, max_length: the min and max length of string options
+//   - choices: the choices of string, int and float options, e.g. "Low=1;High=2"
+//   - channel_types: the allowed discord.ChannelType(s) of channel options, e.g. "0,5"
+//   - autocomplete: "true" to enable autocomplete for string, int and float options
+//   - name_localizations, description_localizations: the localizations of the name and description, e.g. "de=bannen;fr=bannir"
+func SlashCommandFromStruct[T any](name string, description string) (discord.SlashCommandCreate, error) {
+	cmd, err := structCommandOf(reflect.TypeFor[T]())
+	if err != nil {
+		return discord.SlashCommandCreate{}, err
+	}
+	return discord.SlashCommandCreate{
+		Name:        name,
+		Description: description,
+		Options:     cmd.applicationCommandOptions(),
+	}, nil
+}
+
+// MustSlashCommandFromStruct is like SlashCommandFromStruct but panics if T is not a valid command struct.
+func MustSlashCommandFromStruct[T any](name string, description string) discord.SlashCommandCreate {
+	command, err := SlashCommandFromStruct[T](name, description)
+	if err != nil {
+		panic(err)
+	}
+	return command
+}
+
+// DecodeSlashCommand decodes the options of the slash command into T, see SlashCommandFromStruct for the supported fields.
+// The field of the invoked subcommand is set while the fields of all other subcommands are nil.
+// After decoding, the Validate() error method of each decoded struct is called if it is implemented.
+// Options which are missing or don't satisfy the constraints of their field as well as errors returned by Validate are returned as ValidationError.
+func DecodeSlashCommand[T any](data discord.SlashCommandInteractionData) (T, error) {
+	var v T
+	cmd, err := structCommandOf(reflect.TypeFor[T]())
+	if err != nil {
+		return v, err
+	}
+
+	var path []string
+	if data.SubCommandGroupName != nil {
+		path = append(path, *data.SubCommandGroupName)
+	}
+	if data.SubCommandName != nil {
+		path = append(path, *data.SubCommandName)
+	}
+	if err = cmd.decode(reflect.ValueOf(&v).Elem(), data, path); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+var (
+	userType            = reflect.TypeFor[discord.User]()
+	resolvedMemberType  = reflect.TypeFor[discord.ResolvedMember]()
+	resolvedChannelType = reflect.TypeFor[discord.ResolvedChannel]()
+	roleType            = reflect.TypeFor[discord.Role]()
+	mentionableType     = reflect.TypeFor[discord.MentionableValue]()
+	snowflakeType       = reflect.TypeFor[snowflake.ID]()
+	attachmentType      = reflect.TypeFor[discord.Attachment]()
+)
+
+var structCommands sync.Map
+
+// structCommandOf returns the cached structCommand of the given type or parses it.
+func structCommandOf(t reflect.Type) (*structCommand, error) {
+	if cmd, ok := structCommands.Load(t); ok {
+		return cmd.(*structCommand), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("command type %s is not a struct", t)
+	}
+	cmd, err := parseStructCommand(t, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command struct %s: %w", t, err)
+	}
+	structCommands.Store(t, cmd)
+	return cmd, nil
+}
+
+type structCommand struct {
+	options     []structOption
+	subCommands []structSubCommand
+}
+
+type structSubCommand struct {
+	index   []int
+	name    string
+	elem    reflect.Type
+	group   bool
+	command *structCommand
+	option  discord.ApplicationCommandOption
+}
+
+type structOption struct {
+	index      []int
+	name       string
+	elem       reflect.Type
+	pointer    bool
+	optionType discord.ApplicationCommandOptionType
+	required   bool
+	minValue   *float64
+	maxValue   *float64
+	minLength  *int
+	maxLength  *int
+	choices    []any
+	option     discord.ApplicationCommandOption
+}
+
+func parseStructCommand(t reflect.Type, depth int) (*structCommand, error) {
+	cmd := &structCommand{}
+	names := make(map[string]struct{})
+	err := walkStructFields(t, nil, func(field reflect.StructField, index []int) error {
+		name := field.Tag.Get("name")
+		if name == "-" {
+			return nil
+		}
+		if name == "" {
+			name = toSnakeCase(field.Name)
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate option name %q", name)
+		}
+		names[name] = struct{}{}
+
+		if field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct && structOptionType(field.Type.Elem()) == 0 {
+			if depth >= 2 {
+				return fmt.Errorf("field %s: subcommands can only be nested in one subcommand group", field.Name)
+			}
+			subCommand, err := parseStructSubCommand(field, index, name, depth)
+			if err != nil {
+				return err
+			}
+			cmd.subCommands = append(cmd.subCommands, *subCommand)
+			return nil
+		}
+
+		option, err := parseStructOption(field, index, name)
+		if err != nil {
+			return err
+		}
+		cmd.options = append(cmd.options, *option)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(cmd.options) > 0 && len(cmd.subCommands) > 0 {
+		return nil, errors.New("subcommands can't be mixed with other options")
+	}
+	return cmd, nil
+}
+
+// walkStructFields calls fn for all exported fields of t and flattens embedded structs.
+func walkStructFields(t reflect.Type, index []int, fn func(field reflect.StructField, index []int) error) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && structOptionType(field.Type) == 0 {
+			if err := walkStructFields(field.Type, fieldIndex, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if err := fn(field, fieldIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseStructSubCommand(field reflect.StructField, index []int, name string, depth int) (*structSubCommand, error) {
+	elem := field.Type.Elem()
+	command, err := parseStructCommand(elem, depth+1)
+	if err != nil {
+		return nil, fmt.Errorf("subcommand %s: %w", name, err)
+	}
+	description := field.Tag.Get("description")
+	if description == "" {
+		return nil, fmt.Errorf("field %s: missing description tag", field.Name)
+	}
+	nameLocalizations, err := parseLocalizations(field.Tag.Get("name_localizations"))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: invalid name_localizations tag: %w", field.Name, err)
+	}
+	descriptionLocalizations, err := parseLocalizations(field.Tag.Get("description_localizations"))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: invalid description_localizations tag: %w", field.Name, err)
+	}
+
+	subCommand := &structSubCommand{
+		index:   index,
+		name:    name,
+		elem:    elem,
+		group:   len(command.subCommands) > 0,
+		command: command,
+	}
+	if subCommand.group {
+		if depth > 0 {
+			return nil, fmt.Errorf("field %s: subcommand groups can't be nested", field.Name)
+		}
+		options := make([]discord.ApplicationCommandOptionSubCommand, len(command.subCommands))
+		for i, sub := range command.subCommands {
+			options[i] = sub.option.(discord.ApplicationCommandOptionSubCommand)
+		}
+		subCommand.option = discord.ApplicationCommandOptionSubCommandGroup{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Options:                  options,
+		}
+		return subCommand, nil
+	}
+	subCommand.option = discord.ApplicationCommandOptionSubCommand{
+		Name:                     name,
+		NameLocalizations:        nameLocalizations,
+		Description:              description,
+		DescriptionLocalizations: descriptionLocalizations,
+		Options:                  command.applicationCommandOptions(),
+	}
+	return subCommand, nil
+}
+
+func parseStructOption(field reflect.StructField, index []int, name string) (*structOption, error) {
+	option := &structOption{
+		index:    index,
+		name:     name,
+		elem:     field.Type,
+		required: true,
+	}
+	if field.Type.Kind() == reflect.Pointer {
+		option.elem = field.Type.Elem()
+		option.pointer = true
+		option.required = false
+	}
+	option.optionType = structOptionType(option.elem)
+	if option.optionType == 0 {
+		return nil, fmt.Errorf("field %s: unsupported type %s", field.Name, field.Type)
+	}
+
+	tag := field.Tag
+	description := tag.Get("description")
+	if description == "" {
+		return nil, fmt.Errorf("field %s: missing description tag", field.Name)
+	}
+	if required := tag.Get("required"); required != "" {
+		var err error
+		if option.required, err = strconv.ParseBool(required); err != nil {
+			return nil, fmt.Errorf("field %s: invalid required tag: %w", field.Name, err)
+		}
+	}
+	autocomplete := false
+	if value := tag.Get("autocomplete"); value != "" {
+		var err error
+		if autocomplete, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("field %s: invalid autocomplete tag: %w", field.Name, err)
+		}
+	}
+	nameLocalizations, err := parseLocalizations(tag.Get("name_localizations"))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: invalid name_localizations tag: %w", field.Name, err)
+	}
+	descriptionLocalizations, err := parseLocalizations(tag.Get("description_localizations"))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: invalid description_localizations tag: %w", field.Name, err)
+	}
+	if option.minValue, err = parseOptionalTag(tag, "min", parseFloat); err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	if option.maxValue, err = parseOptionalTag(tag, "max", parseFloat); err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	if option.minLength, err = parseOptionalTag(tag, "min_length", strconv.Atoi); err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	if option.maxLength, err = parseOptionalTag(tag, "max_length", strconv.Atoi); err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	if (option.minValue != nil || option.maxValue != nil) && option.optionType != discord.ApplicationCommandOptionTypeInt && option.optionType != discord.ApplicationCommandOptionTypeFloat {
+		return nil, fmt.Errorf("field %s: min and max are only supported for int and float options", field.Name)
+	}
+	if option.optionType == discord.ApplicationCommandOptionTypeInt && (!isWholeNumber(option.minValue) || !isWholeNumber(option.maxValue)) {
+		return nil, fmt.Errorf("field %s: min and max of int options must be whole numbers", field.Name)
+	}
+	if (option.minLength != nil || option.maxLength != nil) && option.optionType != discord.ApplicationCommandOptionTypeString {
+		return nil, fmt.Errorf("field %s: min_length and max_length are only supported for string options", field.Name)
+	}
+
+	var channelTypes []discord.ChannelType
+	if value := tag.Get("channel_types"); value != "" {
+		if option.optionType != discord.ApplicationCommandOptionTypeChannel {
+			return nil, fmt.Errorf("field %s: channel_types is only supported for channel options", field.Name)
+		}
+		for _, part := range strings.Split(value, ",") {
+			channelType, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: invalid channel type %q", field.Name, part)
+			}
+			channelTypes = append(channelTypes, discord.ChannelType(channelType))
+		}
+	}
+
+	var choiceNames []string
+	if value := tag.Get("choices"); value != "" {
+		if autocomplete {
+			return nil, fmt.Errorf("field %s: choices can't be combined with autocomplete", field.Name)
+		}
+		for _, part := range strings.Split(value, ";") {
+			choiceName, choiceValue, ok := strings.Cut(part, "=")
+			if !ok {
+				choiceValue = choiceName
+			}
+			var v any
+			switch option.optionType {
+			case discord.ApplicationCommandOptionTypeString:
+				v = choiceValue
+			case discord.ApplicationCommandOptionTypeInt:
+				v, err = strconv.Atoi(choiceValue)
+			case discord.ApplicationCommandOptionTypeFloat:
+				v, err = strconv.ParseFloat(choiceValue, 64)
+			default:
+				return nil, fmt.Errorf("field %s: choices are only supported for string, int and float options", field.Name)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("field %s: invalid choice %q: %w", field.Name, part, err)
+			}
+			choiceNames = append(choiceNames, choiceName)
+			option.choices = append(option.choices, v)
+		}
+	}
+
+	switch option.optionType {
+	case discord.ApplicationCommandOptionTypeString:
+		var choices []discord.ApplicationCommandOptionChoiceString
+		for i, choice := range option.choices {
+			choices = append(choices, discord.ApplicationCommandOptionChoiceString{Name: choiceNames[i], Value: choice.(string)})
+		}
+		option.option = discord.ApplicationCommandOptionString{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+			Choices:                  choices,
+			Autocomplete:             autocomplete,
+			MinLength:                option.minLength,
+			MaxLength:                option.maxLength,
+		}
+	case discord.ApplicationCommandOptionTypeInt:
+		var choices []discord.ApplicationCommandOptionChoiceInt
+		for i, choice := range option.choices {
+			choices = append(choices, discord.ApplicationCommandOptionChoiceInt{Name: choiceNames[i], Value: choice.(int)})
+		}
+		option.option = discord.ApplicationCommandOptionInt{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+			Choices:                  choices,
+			Autocomplete:             autocomplete,
+			MinValue:                 floatToInt(option.minValue),
+			MaxValue:                 floatToInt(option.maxValue),
+		}
+	case discord.ApplicationCommandOptionTypeFloat:
+		var choices []discord.ApplicationCommandOptionChoiceFloat
+		for i, choice := range option.choices {
+			choices = append(choices, discord.ApplicationCommandOptionChoiceFloat{Name: choiceNames[i], Value: choice.(float64)})
+		}
+		option.option = discord.ApplicationCommandOptionFloat{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+			Choices:                  choices,
+			Autocomplete:             autocomplete,
+			MinValue:                 option.minValue,
+			MaxValue:                 option.maxValue,
+		}
+	case discord.ApplicationCommandOptionTypeBool:
+		option.option = discord.ApplicationCommandOptionBool{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+		}
+	case discord.ApplicationCommandOptionTypeUser:
+		option.option = discord.ApplicationCommandOptionUser{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+		}
+	case discord.ApplicationCommandOptionTypeChannel:
+		option.option = discord.ApplicationCommandOptionChannel{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+			ChannelTypes:             channelTypes,
+		}
+	case discord.ApplicationCommandOptionTypeRole:
+		option.option = discord.ApplicationCommandOptionRole{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+		}
+	case discord.ApplicationCommandOptionTypeMentionable:
+		option.option = discord.ApplicationCommandOptionMentionable{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+		}
+	case discord.ApplicationCommandOptionTypeAttachment:
+		option.option = discord.ApplicationCommandOptionAttachment{
+			Name:                     name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 option.required,
+		}
+	}
+	return option, nil
+}
+
+// structOptionType returns the discord.ApplicationCommandOptionType of the given field type or 0 if it is not supported.
+func structOptionType(t reflect.Type) discord.ApplicationCommandOptionType {
+	switch t {
+	case userType, resolvedMemberType:
+		return discord.ApplicationCommandOptionTypeUser
+	case resolvedChannelType:
+		return discord.ApplicationCommandOptionTypeChannel
+	case roleType:
+		return discord.ApplicationCommandOptionTypeRole
+	case mentionableType, snowflakeType:
+		return discord.ApplicationCommandOptionTypeMentionable
+	case attachmentType:
+		return discord.ApplicationCommandOptionTypeAttachment
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return discord.ApplicationCommandOptionTypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return discord.ApplicationCommandOptionTypeInt
+	case reflect.Float32, reflect.Float64:
+		return discord.ApplicationCommandOptionTypeFloat
+	case reflect.Bool:
+		return discord.ApplicationCommandOptionTypeBool
+	}
+	return 0
+}
+
+func (c *structCommand) applicationCommandOptions() []discord.ApplicationCommandOption {
+	var options []discord.ApplicationCommandOption
+	for _, subCommand := range c.subCommands {
+		options = append(options, subCommand.option)
+	}
+	// Discord requires required options to be listed before optional ones
+	for _, option := range c.options {
+		if option.required {
+			options = append(options, option.option)
+		}
+	}
+	for _, option := range c.options {
+		if !option.required {
+			options = append(options, option.option)
+		}
+	}
+	return options
+}
+
+func (c *structCommand) decode(v reflect.Value, data discord.SlashCommandInteractionData, path []string) error {
+	if len(c.subCommands) > 0 {
+		if len(path) == 0 {
+			return errors.New("no subcommand was invoked")
+		}
+		for _, subCommand := range c.subCommands {
+			if subCommand.name != path[0] {
+				continue
+			}
+			sub := reflect.New(subCommand.elem)
+			if err := subCommand.command.decode(sub.Elem(), data, path[1:]); err != nil {
+				return err
+			}
+			v.FieldByIndex(subCommand.index).Set(sub)
+			return validateStruct(v)
+		}
+		return fmt.Errorf("unknown subcommand %q", path[0])
+	}
+
+	for _, option := range c.options {
+		if err := option.decode(v.FieldByIndex(option.index), data); err != nil {
+			return err
+		}
+	}
+	return validateStruct(v)
+}
+
+func (o structOption) decode(field reflect.Value, data discord.SlashCommandInteractionData) error {
+	dataOption, ok := data.Option(o.name)
+	if !ok {
+		if o.required {
+			return &ValidationError{Option: o.name, Err: errors.New("is required")}
+		}
+		return nil
+	}
+	if dataOption.Type != o.optionType {
+		return fmt.Errorf("option %s has type %d but expected %d", o.name, dataOption.Type, o.optionType)
+	}
+
+	var value reflect.Value
+	switch o.elem {
+	case userType:
+		user, _ := data.OptUser(o.name)
+		value = reflect.ValueOf(user)
+	case resolvedMemberType:
+		member, ok := data.OptMember(o.name)
+		if !ok {
+			return &ValidationError{Option: o.name, Err: errors.New("must be a member of this server")}
+		}
+		value = reflect.ValueOf(member)
+	case resolvedChannelType:
+		channel, _ := data.OptChannel(o.name)
+		value = reflect.ValueOf(channel)
+	case roleType:
+		role, _ := data.OptRole(o.name)
+		value = reflect.ValueOf(role)
+	case mentionableType:
+		mentionable, _ := data.OptMentionable(o.name)
+		value = reflect.ValueOf(&mentionable).Elem()
+	case snowflakeType:
+		value = reflect.ValueOf(dataOption.Snowflake())
+	case attachmentType:
+		attachment, _ := data.OptAttachment(o.name)
+		value = reflect.ValueOf(attachment)
+	default:
+		var err error
+		if value, err = o.decodePrimitive(dataOption); err != nil {
+			return err
+		}
+	}
+
+	if o.pointer {
+		ptr := reflect.New(o.elem)
+		ptr.Elem().Set(value)
+		field.Set(ptr)
+		return nil
+	}
+	field.Set(value)
+	return nil
+}
+
+func (o structOption) decodePrimitive(dataOption discord.SlashCommandOption) (reflect.Value, error) {
+	value := reflect.New(o.elem).Elem()
+	switch o.optionType {
+	case discord.ApplicationCommandOptionTypeString:
+		s := dataOption.String()
+		if err := o.validateChoice(s); err != nil {
+			return value, err
+		}
+		if o.minLength != nil && len([]rune(s)) < *o.minLength {
+			return value, &ValidationError{Option: o.name, Err: fmt.Errorf("must be at least %d characters long", *o.minLength)}
+		}
+		if o.maxLength != nil && len([]rune(s)) > *o.maxLength {
+			return value, &ValidationError{Option: o.name, Err: fmt.Errorf("must be at most %d characters long", *o.maxLength)}
+		}
+		value.SetString(s)
+	case discord.ApplicationCommandOptionTypeInt:
+		i := dataOption.Int()
+		if err := o.validateChoice(i); err != nil {
+			return value, err
+		}
+		if err := o.validateRange(float64(i)); err != nil {
+			return value, err
+		}
+		if value.OverflowInt(int64(i)) {
+			return value, &ValidationError{Option: o.name, Err: errors.New("is out of range")}
+		}
+		value.SetInt(int64(i))
+	case discord.ApplicationCommandOptionTypeFloat:
+		f := dataOption.Float()
+		if err := o.validateChoice(f); err != nil {
+			return value, err
+		}
+		if err := o.validateRange(f); err != nil {
+			return value, err
+		}
+		value.SetFloat(f)
+	case discord.ApplicationCommandOptionTypeBool:
+		value.SetBool(dataOption.Bool())
+	}
+	return value, nil
+}
+
+func (o structOption) validateChoice(v any) error {
+	if len(o.choices) == 0 {
+		return nil
+	}
+	for _, choice := range o.choices {
+		if choice == v {
+			return nil
+		}
+	}
+	return &ValidationError{Option: o.name, Err: errors.New("must be one of the choices")}
+}
+
+func (o structOption) validateRange(v float64) error {
+	if o.minValue != nil && v < *o.minValue {
+		return &ValidationError{Option: o.name, Err: fmt.Errorf("must be at least %s", strconv.FormatFloat(*o.minValue, 'f', -1, 64))}
+	}
+	if o.maxValue != nil && v > *o.maxValue {
+		return &ValidationError{Option: o.name, Err: fmt.Errorf("must be at most %s", strconv.FormatFloat(*o.maxValue, 'f', -1, 64))}
+	}
+	return nil
+}
+
+// validateStruct calls the Validate method of the struct if it is implemented and wraps the returned error in a ValidationError.
+func validateStruct(v reflect.Value) error {
+	validator, ok := v.Addr().Interface().(interface{ Validate() error })
+	if !ok {
+		return nil
+	}
+	if err := validator.Validate(); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return err
+		}
+		return &ValidationError{Err: err}
+	}
+	return nil
+}
+
+func parseOptionalTag[T any](tag reflect.StructTag, key string, parse func(string) (T, error)) (*T, error) {
+	value := tag.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	v, err := parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag: %w", key, err)
+	}
+	return &v, nil
+}
+
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// isWholeNumber returns whether the optional float has no fractional part.
+func isWholeNumber(f *float64) bool {
+	return f == nil || *f == math.Trunc(*f)
+}
+
+func floatToInt(f *float64) *int {
+	if f == nil {
+		return nil
+	}
+	i := int(*f)
+	return &i
+}
+
+// parseLocalizations parses localizations in the format "de=bannen;fr=bannir".
+func parseLocalizations(s string) (map[discord.Locale]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	localizations := make(map[discord.Locale]string)
+	for _, part := range strings.Split(s, ";") {
+		locale, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid localization %q", part)
+		}
+		localizations[discord.Locale(strings.TrimSpace(locale))] = value
+	}
+	return localizations, nil
+}
+
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// start a new word at the start of a capitalized word or after a lowercase letter, e.g. UserID -> user_id
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
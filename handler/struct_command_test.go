@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type banCommand struct {
+	UserID     string `description:"The user to ban"`
+	DeleteDays *int   `description:"Days of messages to delete" min:"0" max:"7" name_localizations:"de=tage"`
+	Reason     string `description:"The reason" required:"false" max_length:"10" choices:"Spam=spam;Raid=raid"`
+}
+
+type warnCommand struct {
+	Reason string `description:"The reason"`
+}
+
+type modCommand struct {
+	Ban   *banCommand `description:"Ban a user"`
+	Warns *struct {
+		Add *warnCommand `description:"Add a warning"`
+	} `description:"Manage warnings"`
+}
+
+func (c *warnCommand) Validate() error {
+	if c.Reason == "nope" {
+		return errors.New("nope is not a reason")
+	}
+	return nil
+}
+
+func TestSlashCommandFromStruct(t *testing.T) {
+	command, err := SlashCommandFromStruct[modCommand]("mod", "Moderation commands")
+	if err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	minValue, maxValue := 0, 7
+	expected := discord.SlashCommandCreate{
+		Name:        "mod",
+		Description: "Moderation commands",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ban",
+				Description: "Ban a user",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "user_id",
+						Description: "The user to ban",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:              "delete_days",
+						NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "tage"},
+						Description:       "Days of messages to delete",
+						MinValue:          &minValue,
+						MaxValue:          &maxValue,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:        "reason",
+						Description: "The reason",
+						Choices: []discord.ApplicationCommandOptionChoiceString{
+							{Name: "Spam", Value: "spam"},
+							{Name: "Raid", Value: "raid"},
+						},
+						MaxLength: func() *int { i := 10; return &i }(),
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommandGroup{
+				Name:        "warns",
+				Description: "Manage warnings",
+				Options: []discord.ApplicationCommandOptionSubCommand{
+					{
+						Name:        "add",
+						Description: "Add a warning",
+						Options: []discord.ApplicationCommandOption{
+							discord.ApplicationCommandOptionString{
+								Name:        "reason",
+								Description: "The reason",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, command) {
+		t.Errorf("expected %+v, got %+v", expected, command)
+	}
+
+	type fractionalCommand struct {
+		Days int `description:"Days" min:"0.5"`
+	}
+	if _, err = SlashCommandFromStruct[fractionalCommand]("fractional", "Fractional min"); err == nil {
+		t.Errorf("expected error for a fractional min of an int option")
+	}
+}
+
+func TestDecodeSlashCommand(t *testing.T) {
+	data := []struct {
+		name     string
+		data     string
+		expected modCommand
+		err      string
+	}{
+		{
+			name: "subcommand",
+			data: `{"id":"1","name":"mod","options":[{"name":"ban","type":1,"options":[{"name":"user_id","type":3,"value":"123"},{"name":"delete_days","type":4,"value":3}]}]}`,
+			expected: modCommand{
+				Ban: &banCommand{UserID: "123", DeleteDays: func() *int { i := 3; return &i }()},
+			},
+		},
+		{
+			name: "subcommand group",
+			data: `{"id":"1","name":"mod","options":[{"name":"warns","type":2,"options":[{"name":"add","type":1,"options":[{"name":"reason","type":3,"value":"spam"}]}]}]}`,
+			expected: modCommand{
+				Warns: &struct {
+					Add *warnCommand `description:"Add a warning"`
+				}{Add: &warnCommand{Reason: "spam"}},
+			},
+		},
+		{
+			name: "out of range",
+			data: `{"id":"1","name":"mod","options":[{"name":"ban","type":1,"options":[{"name":"user_id","type":3,"value":"123"},{"name":"delete_days","type":4,"value":8}]}]}`,
+			err:  "invalid option `delete_days`: must be at most 7",
+		},
+		{
+			name: "missing required",
+			data: `{"id":"1","name":"mod","options":[{"name":"ban","type":1,"options":[]}]}`,
+			err:  "invalid option `user_id`: is required",
+		},
+		{
+			name: "validate",
+			data: `{"id":"1","name":"mod","options":[{"name":"warns","type":2,"options":[{"name":"add","type":1,"options":[{"name":"reason","type":3,"value":"nope"}]}]}]}`,
+			err:  "nope is not a reason",
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			var interactionData discord.SlashCommandInteractionData
+			if err := json.Unmarshal([]byte(d.data), &interactionData); err != nil {
+				t.Fatalf("failed to unmarshal interaction data: %v", err)
+			}
+
+			cmd, err := DecodeSlashCommand[modCommand](interactionData)
+			if d.err != "" {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) || err.Error() != d.err {
+					t.Fatalf("expected validation error %q, got %v", d.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode command: %v", err)
+			}
+			if !reflect.DeepEqual(d.expected, cmd) {
+				t.Errorf("expected %+v, got %+v", d.expected, cmd)
+			}
+		})
+	}
+}
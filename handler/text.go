@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type (
+	// TextHandler is a function that handles text commands.
+	TextHandler func(e *TextCommandEvent) error
+
+	// TextMiddleware is a function that wraps a TextHandler to intercept and short-circuit text commands.
+	TextMiddleware func(next TextHandler) TextHandler
+
+	// TextNotFoundHandler is a function that is called when a message starts with a prefix but no command was found.
+	TextNotFoundHandler func(e *TextCommandEvent) error
+
+	// TextErrorHandler is a function that is called when an error occurs during handling a text command.
+	TextErrorHandler = EventErrorHandler[*TextCommandEvent]
+)
+
+var defaultTextErrorHandler TextErrorHandler = func(event *TextCommandEvent, err error) {
+	event.Client().Logger.Error("error handling text command", slog.String("command", event.Pattern), slog.Any("err", err))
+}
+
+// TextCommandEvent allows to handle text commands like "!ban @user 7d spam".
+type TextCommandEvent struct {
+	*events.MessageCreate
+	Ctx context.Context
+	// Pattern is the full pattern of the command without aliases, e.g. /mod/ban.
+	// It is built like the pattern of application commands, so both can share a cooldown.
+	Pattern string
+	// Prefix is the prefix the command was used with, e.g. "!" or the mention of the bot.
+	Prefix string
+	// Args are the arguments after the command and subcommand names.
+	Args TextArgs
+}
+
+// Reply replies to the message of the command.
+func (e *TextCommandEvent) Reply(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
+	if messageCreate.MessageReference == nil {
+		messageCreate.MessageReference = &discord.MessageReference{
+			MessageID: &e.MessageID,
+			ChannelID: &e.ChannelID,
+			GuildID:   e.GuildID,
+		}
+	}
+	return e.Client().Rest.CreateMessage(e.ChannelID, messageCreate, opts...)
+}
+
+// MemberPermissions returns the permissions of the author in the channel calculated from the cache.
+// It returns 0 outside of guilds or if the channel is not cached.
+func (e *TextCommandEvent) MemberPermissions() discord.Permissions {
+	if e.Message.Member == nil {
+		return 0
+	}
+	channel, ok := e.Client().Caches.Channel(e.ChannelID)
+	if !ok {
+		return 0
+	}
+	member := *e.Message.Member
+	member.User = e.Message.Author
+	return e.Client().Caches.MemberPermissionsInChannel(channel, member)
+}
+
+// BotPermissions returns the permissions of the bot in the channel calculated from the cache.
+// It returns 0 outside of guilds or if the channel or the member of the bot is not cached.
+func (e *TextCommandEvent) BotPermissions() discord.Permissions {
+	if e.GuildID == nil {
+		return 0
+	}
+	channel, ok := e.Client().Caches.Channel(e.ChannelID)
+	if !ok {
+		return 0
+	}
+	member, ok := e.Client().Caches.SelfMember(*e.GuildID)
+	if !ok {
+		return 0
+	}
+	return e.Client().Caches.MemberPermissionsInChannel(channel, member)
+}
+
+// TextRouter provides the routing of text commands. Commands and sub-routers are matched by their name or aliases ignoring the case.
+type TextRouter interface {
+	// Use adds the given middlewares to the current TextRouter.
+	Use(middlewares ...TextMiddleware)
+
+	// Group creates a new TextRouter and adds it to the current TextRouter.
+	Group(fn func(r TextRouter))
+
+	// Route creates a new sub-router for subcommands with the given name and aliases and adds it to the current TextRouter.
+	Route(name string, fn func(r TextRouter), aliases ...string) TextRouter
+
+	// Command registers the given TextHandler with the given name and aliases to the current TextRouter.
+	Command(name string, h TextHandler, aliases ...string)
+}
+
+var _ TextRouter = (*TextMux)(nil)
+
+// NewText returns a new TextMux with the TextConfigOpt(s) applied.
+func NewText(opts ...TextConfigOpt) *TextMux {
+	cfg := defaultTextConfig()
+	cfg.apply(opts)
+	return &TextMux{config: cfg}
+}
+
+// TextMux is a TextRouter for text commands in messages, which is used alongside the Mux for interactions.
+// It handles messages starting with one of its prefixes. The content after the prefix is split into the command name,
+// subcommand names and arguments, which can be quoted with double or single quotes.
+//
+//	r := handler.NewText(handler.WithTextPrefixes("!"), handler.WithTextMentionPrefix())
+//	r.Use(middleware.TextGuard(middleware.TextHasPermissions(discord.PermissionBanMembers)))
+//	r.Command("ban", func(e *handler.TextCommandEvent) error {
+//		userID, err := e.Args.User(0)
+//		...
+//	}, "b")
+//	client.AddEventListeners(r)
+//
+// Message content is a privileged intent, which is required for messages which don't mention the bot.
+type TextMux struct {
+	config          textConfig
+	name            string
+	aliases         []string
+	middlewares     []TextMiddleware
+	routes          []any
+	notFoundHandler TextNotFoundHandler
+	errorHandler    TextErrorHandler
+	defaultContext  func() context.Context
+}
+
+type textCommand struct {
+	name    string
+	aliases []string
+	handler TextHandler
+}
+
+// OnEvent is called when a new event is received.
+func (r *TextMux) OnEvent(event bot.Event) {
+	e, ok := event.(*events.MessageCreate)
+	if !ok {
+		return
+	}
+	if r.config.IgnoreBots && (e.Message.Author.Bot || e.Message.WebhookID != nil) {
+		return
+	}
+
+	prefix, ok := r.prefix(e)
+	if !ok {
+		return
+	}
+
+	var ctx context.Context
+	if r.defaultContext != nil {
+		ctx = r.defaultContext()
+	} else {
+		ctx = context.Background()
+	}
+
+	te := &TextCommandEvent{
+		MessageCreate: e,
+		Ctx:           ctx,
+		Prefix:        prefix,
+	}
+	if err := r.handleText(te, e.Message.Content[len(prefix):]); err != nil {
+		if r.errorHandler != nil {
+			r.errorHandler(te, err)
+			return
+		}
+		defaultTextErrorHandler(te, err)
+	}
+}
+
+// prefix returns the longest prefix the message starts with.
+func (r *TextMux) prefix(e *events.MessageCreate) (string, bool) {
+	prefixes := r.config.Prefixes
+	if r.config.PrefixFunc != nil {
+		prefixes = r.config.PrefixFunc(e)
+	}
+	if r.config.MentionPrefix {
+		selfID := e.Client().ID()
+		prefixes = append(prefixes[:len(prefixes):len(prefixes)], "<@"+selfID.String()+">", "<@!"+selfID.String()+">")
+	}
+
+	var prefix string
+	for _, p := range prefixes {
+		if p != "" && len(p) > len(prefix) && strings.HasPrefix(e.Message.Content, p) {
+			prefix = p
+		}
+	}
+	return prefix, prefix != ""
+}
+
+func (r *TextMux) handleText(event *TextCommandEvent, content string) error {
+	// the command is looked up before failing on the arguments, so messages which are no command are ignored
+	tokens, splitErr := splitTextArgs(content)
+	if len(tokens) == 0 && splitErr == nil {
+		return nil
+	}
+
+	routers, command, names := r.find(tokens)
+	if command == nil {
+		if r.notFoundHandler != nil {
+			return r.notFoundHandler(event)
+		}
+		return nil
+	}
+
+	event.Pattern = "/" + strings.Join(names, "/")
+	if splitErr != nil {
+		_, err := event.Reply(r.config.UnclosedQuoteMessage)
+		return err
+	}
+	event.Args = TextArgs{
+		content: content,
+		tokens:  tokens[len(names):],
+	}
+
+	handlerChain := command.handler
+	for i := len(routers) - 1; i >= 0; i-- {
+		for j := len(routers[i].middlewares) - 1; j >= 0; j-- {
+			handlerChain = routers[i].middlewares[j](handlerChain)
+		}
+	}
+	return handlerChain(event)
+}
+
+// find returns the routers leading to the command matching the tokens and the names of the command and its parents.
+func (r *TextMux) find(tokens []textToken) ([]*TextMux, *textCommand, []string) {
+	for _, route := range r.routes {
+		switch rt := route.(type) {
+		case *textCommand:
+			if matchTextName(tokens, rt.name, rt.aliases) {
+				return []*TextMux{r}, rt, []string{rt.name}
+			}
+		case *TextMux:
+			if rt.name == "" {
+				if routers, command, names := rt.find(tokens); command != nil {
+					return append([]*TextMux{r}, routers...), command, names
+				}
+				continue
+			}
+			if matchTextName(tokens, rt.name, rt.aliases) {
+				if routers, command, names := rt.find(tokens[1:]); command != nil {
+					return append([]*TextMux{r}, routers...), command, append([]string{rt.name}, names...)
+				}
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+func matchTextName(tokens []textToken, name string, aliases []string) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	if strings.EqualFold(tokens[0].value, name) {
+		return true
+	}
+	for _, alias := range aliases {
+		if strings.EqualFold(tokens[0].value, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// Use adds the given middlewares to the current TextRouter.
+func (r *TextMux) Use(middlewares ...TextMiddleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Group creates a new TextRouter and adds it to the current TextRouter.
+func (r *TextMux) Group(fn func(r TextRouter)) {
+	router := &TextMux{}
+	fn(router)
+	r.routes = append(r.routes, router)
+}
+
+// Route creates a new sub-router for subcommands with the given name and aliases and adds it to the current TextRouter.
+func (r *TextMux) Route(name string, fn func(r TextRouter), aliases ...string) TextRouter {
+	checkTextName(name)
+	router := &TextMux{
+		name:    name,
+		aliases: aliases,
+	}
+	fn(router)
+	r.routes = append(r.routes, router)
+	return router
+}
+
+// Command registers the given TextHandler with the given name and aliases to the current TextRouter.
+func (r *TextMux) Command(name string, h TextHandler, aliases ...string) {
+	checkTextName(name)
+	r.routes = append(r.routes, &textCommand{
+		name:    name,
+		aliases: aliases,
+		handler: h,
+	})
+}
+
+// NotFound sets the TextNotFoundHandler for this router.
+// This handler only works for the root router and will be ignored for sub routers.
+func (r *TextMux) NotFound(h TextNotFoundHandler) {
+	r.notFoundHandler = h
+}
+
+// Error sets the TextErrorHandler for this router.
+// This handler only works for the root router and will be ignored for sub routers.
+func (r *TextMux) Error(h TextErrorHandler) {
+	r.errorHandler = h
+}
+
+// DefaultContext sets the default context for this router.
+// This context will be used for all text command events.
+func (r *TextMux) DefaultContext(ctx func() context.Context) {
+	r.defaultContext = ctx
+}
+
+func checkTextName(name string) {
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool {
+		return r == '/' || r == ' '
+	}) {
+		panic("name must not be empty or contain / or spaces")
+	}
+}
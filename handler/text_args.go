@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	// ErrMissingArgument is returned by TextArgs if the argument is missing.
+	ErrMissingArgument = errors.New("missing argument")
+	// ErrInvalidArgument is returned by TextArgs if the argument can't be parsed.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrUnclosedQuote is returned if a quoted argument of a text command is not closed.
+	ErrUnclosedQuote = errors.New("unclosed quote")
+)
+
+// ArgumentError is returned by TextArgs if an argument is missing or can't be parsed.
+type ArgumentError struct {
+	Index int
+	Value string
+	Err   error
+}
+
+func (e *ArgumentError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("argument %d: %s", e.Index+1, e.Err)
+	}
+	return fmt.Sprintf("argument %d %q: %s", e.Index+1, e.Value, e.Err)
+}
+
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+type textToken struct {
+	value string
+	start int
+}
+
+// splitTextArgs splits the content at whitespace. Arguments can be quoted with double or single quotes to contain whitespace,
+// and a backslash escapes the next character. Quotes only start a quoted argument at the beginning of an argument, so words like "don't" are kept.
+// If a quote is not closed, the arguments before it are returned with ErrUnclosedQuote.
+func splitTextArgs(content string) ([]textToken, error) {
+	var (
+		tokens  []textToken
+		current strings.Builder
+		start   = -1
+		quote   rune
+		escaped bool
+	)
+	for i, r := range content {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case (r == '"' || r == '\'') && start < 0:
+			quote = r
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				tokens = append(tokens, textToken{value: current.String(), start: start})
+				current.Reset()
+				start = -1
+			}
+			continue
+		default:
+			current.WriteRune(r)
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if quote != 0 {
+		return tokens, ErrUnclosedQuote
+	}
+	if start >= 0 {
+		tokens = append(tokens, textToken{value: current.String(), start: start})
+	}
+	return tokens, nil
+}
+
+// TextArgs are the arguments of a text command after the command and subcommand names.
+type TextArgs struct {
+	content string
+	tokens  []textToken
+}
+
+// Len returns the number of arguments.
+func (a TextArgs) Len() int {
+	return len(a.tokens)
+}
+
+// Strings returns all arguments without quotes.
+func (a TextArgs) Strings() []string {
+	values := make([]string, 0, len(a.tokens))
+	for _, token := range a.tokens {
+		values = append(values, token.value)
+	}
+	return values
+}
+
+// String returns the argument at the index without quotes.
+func (a TextArgs) String(i int) (string, error) {
+	if i < 0 || i >= len(a.tokens) {
+		return "", &ArgumentError{Index: i, Err: ErrMissingArgument}
+	}
+	return a.tokens[i].value, nil
+}
+
+// Rest returns the raw content from the argument at the index to the end, e.g. the reason of a ban.
+func (a TextArgs) Rest(i int) (string, error) {
+	if i < 0 || i >= len(a.tokens) {
+		return "", &ArgumentError{Index: i, Err: ErrMissingArgument}
+	}
+	return strings.TrimSpace(a.content[a.tokens[i].start:]), nil
+}
+
+// Int returns the argument at the index as int.
+func (a TextArgs) Int(i int) (int, error) {
+	return parseTextArg(a, i, strconv.Atoi)
+}
+
+// Float returns the argument at the index as float64.
+func (a TextArgs) Float(i int) (float64, error) {
+	return parseTextArg(a, i, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	})
+}
+
+// Bool returns the argument at the index as bool. It accepts the values of strconv.ParseBool as well as yes, no, on and off.
+func (a TextArgs) Bool(i int) (bool, error) {
+	return parseTextArg(a, i, func(value string) (bool, error) {
+		switch strings.ToLower(value) {
+		case "yes", "on":
+			return true, nil
+		case "no", "off":
+			return false, nil
+		}
+		return strconv.ParseBool(value)
+	})
+}
+
+// Snowflake returns the argument at the index as snowflake.ID.
+func (a TextArgs) Snowflake(i int) (snowflake.ID, error) {
+	return parseTextArg(a, i, snowflake.Parse)
+}
+
+// User returns the ID of the user mention (<@id> or <@!id>) or user ID at the index.
+// The mentioned users are included in the Mentions of the message.
+func (a TextArgs) User(i int) (snowflake.ID, error) {
+	return parseTextArg(a, i, func(value string) (snowflake.ID, error) {
+		return parseMention(value, "<@!", "<@")
+	})
+}
+
+// Role returns the ID of the role mention (<@&id>) or role ID at the index.
+func (a TextArgs) Role(i int) (snowflake.ID, error) {
+	return parseTextArg(a, i, func(value string) (snowflake.ID, error) {
+		return parseMention(value, "<@&")
+	})
+}
+
+// Channel returns the ID of the channel mention (<#id>) or channel ID at the index.
+func (a TextArgs) Channel(i int) (snowflake.ID, error) {
+	return parseTextArg(a, i, func(value string) (snowflake.ID, error) {
+		return parseMention(value, "<#")
+	})
+}
+
+// Duration returns the argument at the index as time.Duration. Durations are a sequence of numbers with the units
+// w (weeks), d (days), h, m and s, e.g. 7d or 1h30m.
+func (a TextArgs) Duration(i int) (time.Duration, error) {
+	return parseTextArg(a, i, parseTextDuration)
+}
+
+func parseTextArg[T any](a TextArgs, i int, parse func(value string) (T, error)) (T, error) {
+	value, err := a.String(i)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := parse(value)
+	if err != nil {
+		var zero T
+		return zero, &ArgumentError{Index: i, Value: value, Err: ErrInvalidArgument}
+	}
+	return v, nil
+}
+
+// parseMention parses a mention with one of the given prefixes or a plain ID.
+func parseMention(value string, prefixes ...string) (snowflake.ID, error) {
+	if strings.HasSuffix(value, ">") {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(value, prefix) {
+				return snowflake.Parse(value[len(prefix) : len(value)-1])
+			}
+		}
+		return 0, ErrInvalidArgument
+	}
+	return snowflake.Parse(value)
+}
+
+var textDurationUnits = map[string]time.Duration{
+	"w": 7 * 24 * time.Hour,
+	"d": 24 * time.Hour,
+	"h": time.Hour,
+	"m": time.Minute,
+	"s": time.Second,
+}
+
+func parseTextDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, ErrInvalidArgument
+	}
+	var duration time.Duration
+	for value != "" {
+		i := strings.IndexFunc(value, func(r rune) bool {
+			return r < '0' || r > '9'
+		})
+		if i <= 0 {
+			return 0, ErrInvalidArgument
+		}
+		n, err := strconv.ParseInt(value[:i], 10, 64)
+		if err != nil {
+			return 0, ErrInvalidArgument
+		}
+		unit, ok := textDurationUnits[strings.ToLower(value[i:i+1])]
+		if !ok {
+			return 0, ErrInvalidArgument
+		}
+		// reject durations which don't fit into a time.Duration instead of wrapping around to a negative total
+		if n > int64(math.MaxInt64/unit) || duration > math.MaxInt64-time.Duration(n)*unit {
+			return 0, ErrInvalidArgument
+		}
+		duration += time.Duration(n) * unit
+		value = value[i+1:]
+	}
+	return duration, nil
+}
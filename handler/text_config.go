@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func defaultTextConfig() textConfig {
+	return textConfig{
+		IgnoreBots: true,
+		UnclosedQuoteMessage: discord.MessageCreate{
+			Content: "Please close the quote of your arguments.",
+		},
+	}
+}
+
+type textConfig struct {
+	Prefixes             []string
+	PrefixFunc           func(e *events.MessageCreate) []string
+	MentionPrefix        bool
+	IgnoreBots           bool
+	UnclosedQuoteMessage discord.MessageCreate
+}
+
+// TextConfigOpt is a functional option for configuring a TextMux.
+type TextConfigOpt func(config *textConfig)
+
+func (c *textConfig) apply(opts []TextConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithTextPrefixes sets the prefixes of the text commands, e.g. "!".
+func WithTextPrefixes(prefixes ...string) TextConfigOpt {
+	return func(config *textConfig) {
+		config.Prefixes = append(config.Prefixes, prefixes...)
+	}
+}
+
+// WithTextPrefixFunc sets a function which returns the prefixes for a message, e.g. to allow custom prefixes per guild.
+// It overrides the prefixes of WithTextPrefixes.
+func WithTextPrefixFunc(prefixFunc func(e *events.MessageCreate) []string) TextConfigOpt {
+	return func(config *textConfig) {
+		config.PrefixFunc = prefixFunc
+	}
+}
+
+// WithTextMentionPrefix allows to use the mention of the bot as prefix, e.g. "@bot ban @user".
+func WithTextMentionPrefix() TextConfigOpt {
+	return func(config *textConfig) {
+		config.MentionPrefix = true
+	}
+}
+
+// WithTextIgnoreBots sets whether messages of bots and webhooks are ignored. Defaults to true.
+func WithTextIgnoreBots(ignore bool) TextConfigOpt {
+	return func(config *textConfig) {
+		config.IgnoreBots = ignore
+	}
+}
+
+// WithTextUnclosedQuoteMessage overrides the message which is replied when the arguments of a command contain a quote which is not closed.
+func WithTextUnclosedQuoteMessage(message discord.MessageCreate) TextConfigOpt {
+	return func(config *textConfig) {
+		config.UnclosedQuoteMessage = message
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestSplitTextArgs(t *testing.T) {
+	data := []struct {
+		content  string
+		expected []string
+		err      error
+	}{
+		{content: "ban @user 7d", expected: []string{"ban", "@user", "7d"}},
+		{content: "  say   \"hello world\"  'it s' ", expected: []string{"say", "hello world", "it s"}},
+		{content: "ban <@1> 7d don't spam", expected: []string{"ban", "<@1>", "7d", "don't", "spam"}},
+		{content: "it's broken", expected: []string{"it's", "broken"}},
+		{content: `say \"quoted\" "a \" b" ""`, expected: []string{"say", `"quoted"`, `a " b`, ""}},
+		{content: `say "unclosed`, expected: []string{"say"}, err: ErrUnclosedQuote},
+	}
+
+	for _, d := range data {
+		tokens, err := splitTextArgs(d.content)
+		if !errors.Is(err, d.err) {
+			t.Errorf("content %q: expected error %v, got %v", d.content, d.err, err)
+			continue
+		}
+		args := TextArgs{content: d.content, tokens: tokens}
+		if !reflect.DeepEqual(d.expected, args.Strings()) {
+			t.Errorf("content %q: expected %q, got %q", d.content, d.expected, args.Strings())
+		}
+	}
+}
+
+func TestParseTextDuration(t *testing.T) {
+	data := map[string]time.Duration{
+		"7d":    7 * 24 * time.Hour,
+		"1h30m": 90 * time.Minute,
+		"2w1d":  15 * 24 * time.Hour,
+		"45s":   45 * time.Second,
+		"":      -1,
+		"7":     -1,
+		"d":     -1,
+		"5y":    -1,
+		// overflowing durations
+		"15251w":                -1,
+		"15250w1000000s":        -1,
+		"99999999999999999999s": -1,
+	}
+
+	for value, expected := range data {
+		duration, err := parseTextDuration(value)
+		if expected < 0 {
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("value %q: expected ErrInvalidArgument, got %s (%v)", value, duration, err)
+			}
+			continue
+		}
+		if err != nil || duration != expected {
+			t.Errorf("value %q: expected %s, got %s (%v)", value, expected, duration, err)
+		}
+	}
+}
+
+func TestTextMux(t *testing.T) {
+	var (
+		calls   []string
+		pattern string
+		args    TextArgs
+	)
+	middleware := func(name string) TextMiddleware {
+		return func(next TextHandler) TextHandler {
+			return func(e *TextCommandEvent) error {
+				calls = append(calls, name)
+				return next(e)
+			}
+		}
+	}
+	handler := func(e *TextCommandEvent) error {
+		pattern = e.Pattern
+		args = e.Args
+		return nil
+	}
+
+	restClient := &testRestClient{}
+	client := &bot.Client{Rest: rest.New(restClient)}
+	var notFound int
+
+	r := NewText(WithTextPrefixes("!", "!!"))
+	r.NotFound(func(*TextCommandEvent) error {
+		notFound++
+		return nil
+	})
+	r.Use(middleware("root"))
+	r.Command("ban", handler, "b")
+	r.Route("config", func(r TextRouter) {
+		r.Use(middleware("config"))
+		r.Command("set", handler)
+	}, "cfg")
+	r.Group(func(r TextRouter) {
+		r.Use(middleware("group"))
+		r.Command("ping", handler)
+	})
+
+	send := func(content string) {
+		calls, pattern, args, notFound, restClient.bodies = nil, "", TextArgs{}, 0, nil
+		r.OnEvent(&events.MessageCreate{
+			GenericMessage: &events.GenericMessage{
+				GenericEvent: events.NewGenericEvent(client, 0, 0),
+				Message: discord.Message{
+					Content: content,
+					Author:  discord.User{ID: 1},
+				},
+			},
+		})
+	}
+
+	send("!B <@!123> 7d spam  and   eggs")
+	if pattern != "/ban" || !reflect.DeepEqual(calls, []string{"root"}) {
+		t.Fatalf("expected /ban with root middleware, got %q %v", pattern, calls)
+	}
+	if userID, err := args.User(0); err != nil || userID != snowflake.ID(123) {
+		t.Errorf("expected user 123, got %s (%v)", userID, err)
+	}
+	if duration, err := args.Duration(1); err != nil || duration != 7*24*time.Hour {
+		t.Errorf("expected 7d, got %s (%v)", duration, err)
+	}
+	if reason, err := args.Rest(2); err != nil || reason != "spam  and   eggs" {
+		t.Errorf("expected rest of line, got %q (%v)", reason, err)
+	}
+	if _, err := args.Role(0); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+	if _, err := args.String(5); !errors.Is(err, ErrMissingArgument) {
+		t.Errorf("expected missing argument error, got %v", err)
+	}
+
+	send("!!cfg set prefix ?")
+	if pattern != "/config/set" || !reflect.DeepEqual(calls, []string{"root", "config"}) || args.Len() != 2 {
+		t.Errorf("expected /config/set with root and config middlewares, got %q %v %v", pattern, calls, args.Strings())
+	}
+
+	send("!ping")
+	if pattern != "/ping" || !reflect.DeepEqual(calls, []string{"root", "group"}) {
+		t.Errorf("expected /ping with root and group middlewares, got %q %v", pattern, calls)
+	}
+
+	for _, content := range []string{"!config unknown", "ping", "!"} {
+		send(content)
+		if pattern != "" || calls != nil {
+			t.Errorf("content %q: expected no command, got %q %v", content, pattern, calls)
+		}
+	}
+
+	send("!ban <@1> 'unclosed reason")
+	if calls != nil || len(restClient.bodies) != 1 || restClient.bodies[0].(discord.MessageCreate).Content != r.config.UnclosedQuoteMessage.Content {
+		t.Errorf("expected unclosed quote reply without calling the command, got %v %+v", calls, restClient.bodies)
+	}
+
+	send("!unknown 'unclosed")
+	if notFound != 1 || len(restClient.bodies) != 0 {
+		t.Errorf("expected unknown command with unclosed quote to fall through to not found, got %d %+v", notFound, restClient.bodies)
+	}
+}
+
+var _ rest.Client = (*testRestClient)(nil)
+
+// testRestClient records the endpoints and bodies of all requests instead of sending them to Discord.
+type testRestClient struct {
+	endpoints []*rest.CompiledEndpoint
+	bodies    []any
+}
+
+func (c *testRestClient) HTTPClient() *http.Client {
+	return http.DefaultClient
+}
+
+func (c *testRestClient) RateLimiter() rest.RateLimiter {
+	return nil
+}
+
+func (c *testRestClient) Close(_ context.Context) {}
+
+func (c *testRestClient) Do(endpoint *rest.CompiledEndpoint, rqBody any, _ any, _ ...rest.RequestOpt) error {
+	c.endpoints = append(c.endpoints, endpoint)
+	c.bodies = append(c.bodies, rqBody)
+	return nil
+}
@@ -0,0 +1,315 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	_ Bundle     = (*bundleImpl)(nil)
+	_ Translator = (*translatorImpl)(nil)
+)
+
+// Args are the values of the placeholders of a message. A placeholder {name} is replaced with the value of the key name.
+type Args map[string]any
+
+// Bundle holds the message catalogs of all locales.
+type Bundle interface {
+	// Locales returns all locales which have a message catalog.
+	Locales() []discord.Locale
+
+	// DefaultLocale returns the locale which is used as last fallback.
+	DefaultLocale() discord.Locale
+
+	// Translator returns a Translator which looks up messages in the given locales in order, followed by their fallbacks and the default locale.
+	Translator(locales ...discord.Locale) Translator
+
+	// LocalizeCommands returns copies of the given commands with the name, description and choice localizations filled from the message catalogs.
+	// The messages are looked up by key:
+	//   - commands.<command>.name and commands.<command>.description
+	//   - commands.<command>.options.<option>.name and commands.<command>.options.<option>.description
+	//   - commands.<command>.options.<option>.choices.<choice name>
+	//
+	// Options of subcommands and subcommand groups are nested the same way, e.g. commands.mod.options.ban.options.user.description.
+	// Localizations which are already set are kept. An empty description is set from the default locale.
+	LocalizeCommands(commands []discord.ApplicationCommandCreate) []discord.ApplicationCommandCreate
+}
+
+// Translator translates messages into a locale with a fallback chain.
+// If a message is not found in any locale, the key is returned.
+type Translator interface {
+	// Locale returns the first locale of the fallback chain.
+	Locale() discord.Locale
+
+	// Has returns whether the message with the given key exists in any locale of the fallback chain.
+	Has(key string) bool
+
+	// T returns the message with the given key and its placeholders replaced with the given Args.
+	T(key string, args Args) string
+
+	// N returns the plural form of the message with the given key for count and its placeholders replaced with the given Args.
+	// The placeholder {count} is replaced with count.
+	N(key string, count int, args Args) string
+}
+
+// NewBundle loads the message catalogs from the JSON files in the root of fsys and returns a new Bundle with the BundleConfigOpt(s) applied.
+// Each file is named after its locale, e.g. en-US.json or de.json.
+//
+// Messages are either strings or plural messages, which are objects with the keys zero, one, two, few, many and other.
+// Nested objects are flattened with dots, e.g. {"commands": {"ping": {"description": "Ping!"}}} defines the key commands.ping.description.
+// The zero form is used for a count of 0 if it exists regardless of the PluralRule of the locale.
+func NewBundle(fsys fs.FS, opts ...BundleConfigOpt) (Bundle, error) {
+	cfg := defaultBundleConfig()
+	cfg.apply(opts)
+
+	b := &bundleImpl{
+		config:   cfg,
+		catalogs: make(map[discord.Locale]map[string]message),
+	}
+
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog %s: %w", file, err)
+		}
+		var raw map[string]any
+		if err = json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal catalog %s: %w", file, err)
+		}
+		catalog := make(map[string]message)
+		if err = flattenCatalog("", raw, catalog); err != nil {
+			return nil, fmt.Errorf("invalid catalog %s: %w", file, err)
+		}
+		b.catalogs[discord.Locale(strings.TrimSuffix(path.Base(file), ".json"))] = catalog
+	}
+	return b, nil
+}
+
+type message struct {
+	text   string
+	plural map[PluralForm]string
+}
+
+type bundleImpl struct {
+	config   bundleConfig
+	catalogs map[discord.Locale]map[string]message
+}
+
+func flattenCatalog(prefix string, raw map[string]any, catalog map[string]message) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			catalog[key] = message{text: v}
+		case map[string]any:
+			if plural, ok := pluralMessage(v); ok {
+				catalog[key] = message{plural: plural}
+				continue
+			}
+			if err := flattenCatalog(key, v, catalog); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %s must be a string or an object", key)
+		}
+	}
+	return nil
+}
+
+// pluralMessage returns the plural forms of the object if all of its keys are plural forms and it has the other form.
+func pluralMessage(v map[string]any) (map[PluralForm]string, bool) {
+	if _, ok := v[string(PluralOther)]; !ok {
+		return nil, false
+	}
+	plural := make(map[PluralForm]string, len(v))
+	for key, value := range v {
+		s, ok := value.(string)
+		if !ok || !slices.Contains(pluralForms, PluralForm(key)) {
+			return nil, false
+		}
+		plural[PluralForm(key)] = s
+	}
+	return plural, true
+}
+
+func (b *bundleImpl) Locales() []discord.Locale {
+	locales := make([]discord.Locale, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+func (b *bundleImpl) DefaultLocale() discord.Locale {
+	return b.config.DefaultLocale
+}
+
+func (b *bundleImpl) Translator(locales ...discord.Locale) Translator {
+	var chain []discord.Locale
+	add := func(locale discord.Locale) {
+		if _, ok := b.catalogs[locale]; ok && !slices.Contains(chain, locale) {
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, locale := range locales {
+		add(locale)
+		for _, fallback := range b.config.Fallbacks[locale] {
+			add(fallback)
+		}
+		// fall back to other locales of the same language, e.g. en-GB -> en -> en-US
+		language, _, _ := strings.Cut(string(locale), "-")
+		add(discord.Locale(language))
+		for _, other := range b.Locales() {
+			if strings.HasPrefix(string(other), language+"-") {
+				add(other)
+			}
+		}
+	}
+	add(b.config.DefaultLocale)
+
+	locale := b.config.DefaultLocale
+	if len(locales) > 0 {
+		locale = locales[0]
+	}
+	return &translatorImpl{
+		bundle: b,
+		locale: locale,
+		chain:  chain,
+	}
+}
+
+func (b *bundleImpl) pluralRule(locale discord.Locale) PluralRule {
+	if rule, ok := b.config.PluralRules[locale]; ok {
+		return rule
+	}
+	return defaultPluralRule(locale)
+}
+
+type translatorImpl struct {
+	bundle *bundleImpl
+	locale discord.Locale
+	chain  []discord.Locale
+}
+
+func (t *translatorImpl) Locale() discord.Locale {
+	return t.locale
+}
+
+func (t *translatorImpl) lookup(key string) (message, discord.Locale, bool) {
+	for _, locale := range t.chain {
+		if msg, ok := t.bundle.catalogs[locale][key]; ok {
+			return msg, locale, true
+		}
+	}
+	t.bundle.config.Logger.Debug("missing message", slog.String("key", key), slog.String("locale", string(t.locale)))
+	return message{}, "", false
+}
+
+func (t *translatorImpl) Has(key string) bool {
+	for _, locale := range t.chain {
+		if _, ok := t.bundle.catalogs[locale][key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *translatorImpl) T(key string, args Args) string {
+	msg, _, ok := t.lookup(key)
+	if !ok {
+		return key
+	}
+	text := msg.text
+	if msg.plural != nil {
+		text = msg.plural[PluralOther]
+	}
+	return replacePlaceholders(text, args)
+}
+
+func (t *translatorImpl) N(key string, count int, args Args) string {
+	msg, locale, ok := t.lookup(key)
+	if !ok {
+		return key
+	}
+
+	text := msg.text
+	if msg.plural != nil {
+		var hasForm bool
+		if count == 0 {
+			text, hasForm = msg.plural[PluralZero]
+		}
+		if !hasForm {
+			text, hasForm = msg.plural[t.bundle.pluralRule(locale)(count)]
+		}
+		if !hasForm {
+			text = msg.plural[PluralOther]
+		}
+	}
+
+	countArgs := make(Args, len(args)+1)
+	for k, v := range args {
+		countArgs[k] = v
+	}
+	countArgs["count"] = count
+	return replacePlaceholders(text, countArgs)
+}
+
+// replacePlaceholders replaces all placeholders {name} with the values of args. Unknown placeholders are kept.
+func replacePlaceholders(text string, args Args) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end == -1 {
+			break
+		}
+		end += start
+
+		b.WriteString(text[:start])
+		if value, ok := args[text[start+1:end]]; ok {
+			b.WriteString(formatArg(value))
+		} else {
+			b.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+func formatArg(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
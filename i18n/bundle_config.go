@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"log/slog"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultBundleConfig() bundleConfig {
+	return bundleConfig{
+		Logger:        slog.Default(),
+		DefaultLocale: discord.LocaleEnglishUS,
+		PluralRules:   make(map[discord.Locale]PluralRule),
+		Fallbacks:     make(map[discord.Locale][]discord.Locale),
+	}
+}
+
+type bundleConfig struct {
+	Logger        *slog.Logger
+	DefaultLocale discord.Locale
+	PluralRules   map[discord.Locale]PluralRule
+	Fallbacks     map[discord.Locale][]discord.Locale
+}
+
+// BundleConfigOpt is a functional option for configuring a Bundle.
+type BundleConfigOpt func(config *bundleConfig)
+
+func (c *bundleConfig) apply(opts []BundleConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "i18n_bundle"))
+}
+
+// WithBundleLogger overrides the default Logger in the bundleConfig.
+func WithBundleLogger(logger *slog.Logger) BundleConfigOpt {
+	return func(config *bundleConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithBundleDefaultLocale sets the locale which is used as last fallback and for the default name and description of commands.
+// Defaults to discord.LocaleEnglishUS.
+func WithBundleDefaultLocale(locale discord.Locale) BundleConfigOpt {
+	return func(config *bundleConfig) {
+		config.DefaultLocale = locale
+	}
+}
+
+// WithBundlePluralRule overrides the PluralRule of the given locale.
+func WithBundlePluralRule(locale discord.Locale, rule PluralRule) BundleConfigOpt {
+	return func(config *bundleConfig) {
+		config.PluralRules[locale] = rule
+	}
+}
+
+// WithBundleFallbacks sets the locales which are tried after the given locale and before the locales of the same language.
+func WithBundleFallbacks(locale discord.Locale, fallbacks ...discord.Locale) BundleConfigOpt {
+	return func(config *bundleConfig) {
+		config.Fallbacks[locale] = append(config.Fallbacks[locale], fallbacks...)
+	}
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var testCatalogs = fstest.MapFS{
+	"en-US.json": {Data: []byte(`{
+		"greeting": "Hello {user}!",
+		"warnings": {"zero": "No warnings", "one": "{count} warning", "other": "{count} warnings"},
+		"commands": {
+			"ping": {"description": "Replies with pong"},
+			"mod": {"options": {"ban": {"options": {"reason": {"choices": {"Spam": "Spam"}}}}}}
+		}
+	}`)},
+	"de.json": {Data: []byte(`{
+		"greeting": "Hallo {user}!",
+		"commands": {
+			"ping": {"description": "Antwortet mit Pong"},
+			"mod": {"options": {"ban": {"name": "bannen", "options": {"reason": {"choices": {"Spam": "Werbung"}}}}}}
+		}
+	}`)},
+	"ru.json": {Data: []byte(`{
+		"warnings": {"one": "{count} предупреждение", "few": "{count} предупреждения", "many": "{count} предупреждений", "other": "{count} предупреждения"}
+	}`)},
+}
+
+func TestTranslator(t *testing.T) {
+	bundle, err := NewBundle(testCatalogs)
+	if err != nil {
+		t.Fatalf("failed to create bundle: %v", err)
+	}
+
+	data := []struct {
+		locales  []discord.Locale
+		key      string
+		count    int
+		expected string
+	}{
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "greeting", expected: "Hallo Wumpus!"},
+		{locales: []discord.Locale{discord.LocaleEnglishGB}, key: "greeting", expected: "Hello Wumpus!"},
+		{locales: []discord.Locale{discord.LocaleFrench, discord.LocaleGerman}, key: "greeting", expected: "Hallo Wumpus!"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "warnings", count: 0, expected: "No warnings"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "warnings", count: 1, expected: "1 warning"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "warnings", count: 2, expected: "2 warnings"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "warnings", count: 21, expected: "21 предупреждение"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "warnings", count: 3, expected: "3 предупреждения"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "warnings", count: 11, expected: "11 предупреждений"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "missing", expected: "missing"},
+	}
+
+	for _, d := range data {
+		translator := bundle.Translator(d.locales...)
+		var actual string
+		if d.key == "warnings" {
+			actual = translator.N(d.key, d.count, nil)
+		} else {
+			actual = translator.T(d.key, Args{"user": "Wumpus"})
+		}
+		if actual != d.expected {
+			t.Errorf("%v %s (%d): expected %q, got %q", d.locales, d.key, d.count, d.expected, actual)
+		}
+	}
+}
+
+func TestLocalizeCommands(t *testing.T) {
+	bundle, err := NewBundle(testCatalogs)
+	if err != nil {
+		t.Fatalf("failed to create bundle: %v", err)
+	}
+
+	commands := bundle.LocalizeCommands([]discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "ping"},
+		discord.SlashCommandCreate{
+			Name:        "mod",
+			Description: "Moderation",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:        "ban",
+					Description: "Ban a user",
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:        "reason",
+							Description: "The reason",
+							Choices:     []discord.ApplicationCommandOptionChoiceString{{Name: "Spam", Value: "spam"}},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	expected := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:                     "ping",
+			Description:              "Replies with pong",
+			DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Antwortet mit Pong"},
+		},
+		discord.SlashCommandCreate{
+			Name:        "mod",
+			Description: "Moderation",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:              "ban",
+					NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "bannen"},
+					Description:       "Ban a user",
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:        "reason",
+							Description: "The reason",
+							Choices: []discord.ApplicationCommandOptionChoiceString{{
+								Name:              "Spam",
+								NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Werbung"},
+								Value:             "spam",
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, commands) {
+		t.Errorf("expected %+v, got %+v", expected, commands)
+	}
+}
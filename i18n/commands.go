@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func (b *bundleImpl) LocalizeCommands(commands []discord.ApplicationCommandCreate) []discord.ApplicationCommandCreate {
+	localized := make([]discord.ApplicationCommandCreate, len(commands))
+	for i, command := range commands {
+		key := "commands." + command.CommandName()
+		switch c := command.(type) {
+		case discord.SlashCommandCreate:
+			c.NameLocalizations = b.localizations(key+".name", c.NameLocalizations)
+			c.Description = b.defaultText(key+".description", c.Description)
+			c.DescriptionLocalizations = b.localizations(key+".description", c.DescriptionLocalizations)
+			c.Options = b.localizeOptions(key, c.Options)
+			command = c
+		case discord.UserCommandCreate:
+			c.NameLocalizations = b.localizations(key+".name", c.NameLocalizations)
+			command = c
+		case discord.MessageCommandCreate:
+			c.NameLocalizations = b.localizations(key+".name", c.NameLocalizations)
+			command = c
+		case discord.EntryPointCommandCreate:
+			c.NameLocalizations = b.localizations(key+".name", c.NameLocalizations)
+			command = c
+		}
+		localized[i] = command
+	}
+	return localized
+}
+
+func (b *bundleImpl) localizeOptions(key string, options []discord.ApplicationCommandOption) []discord.ApplicationCommandOption {
+	if len(options) == 0 {
+		return options
+	}
+	localized := make([]discord.ApplicationCommandOption, len(options))
+	for i, option := range options {
+		optionKey := key + ".options." + option.OptionName()
+		switch o := option.(type) {
+		case discord.ApplicationCommandOptionSubCommand:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			o.Options = b.localizeOptions(optionKey, o.Options)
+			option = o
+		case discord.ApplicationCommandOptionSubCommandGroup:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			subCommands := make([]discord.ApplicationCommandOptionSubCommand, len(o.Options))
+			for ii, subCommand := range o.Options {
+				subCommandKey := optionKey + ".options." + subCommand.Name
+				subCommand.NameLocalizations, subCommand.Description, subCommand.DescriptionLocalizations = b.localizeOption(subCommandKey, subCommand.NameLocalizations, subCommand.Description, subCommand.DescriptionLocalizations)
+				subCommand.Options = b.localizeOptions(subCommandKey, subCommand.Options)
+				subCommands[ii] = subCommand
+			}
+			o.Options = subCommands
+			option = o
+		case discord.ApplicationCommandOptionString:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			choices := slices.Clone(o.Choices)
+			for ii, choice := range choices {
+				choices[ii].NameLocalizations = b.localizations(optionKey+".choices."+choice.Name, choice.NameLocalizations)
+			}
+			o.Choices = choices
+			option = o
+		case discord.ApplicationCommandOptionInt:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			choices := slices.Clone(o.Choices)
+			for ii, choice := range choices {
+				choices[ii].NameLocalizations = b.localizations(optionKey+".choices."+choice.Name, choice.NameLocalizations)
+			}
+			o.Choices = choices
+			option = o
+		case discord.ApplicationCommandOptionFloat:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			choices := slices.Clone(o.Choices)
+			for ii, choice := range choices {
+				choices[ii].NameLocalizations = b.localizations(optionKey+".choices."+choice.Name, choice.NameLocalizations)
+			}
+			o.Choices = choices
+			option = o
+		case discord.ApplicationCommandOptionBool:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		case discord.ApplicationCommandOptionUser:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		case discord.ApplicationCommandOptionChannel:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		case discord.ApplicationCommandOptionRole:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		case discord.ApplicationCommandOptionMentionable:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		case discord.ApplicationCommandOptionAttachment:
+			o.NameLocalizations, o.Description, o.DescriptionLocalizations = b.localizeOption(optionKey, o.NameLocalizations, o.Description, o.DescriptionLocalizations)
+			option = o
+		}
+		localized[i] = option
+	}
+	return localized
+}
+
+func (b *bundleImpl) localizeOption(key string, nameLocalizations map[discord.Locale]string, description string, descriptionLocalizations map[discord.Locale]string) (map[discord.Locale]string, string, map[discord.Locale]string) {
+	return b.localizations(key+".name", nameLocalizations), b.defaultText(key+".description", description), b.localizations(key+".description", descriptionLocalizations)
+}
+
+// defaultText returns the text if it is set or the message of the default locale.
+func (b *bundleImpl) defaultText(key string, text string) string {
+	if text != "" {
+		return text
+	}
+	if msg, ok := b.catalogs[b.config.DefaultLocale][key]; ok && msg.plural == nil {
+		return msg.text
+	}
+	return text
+}
+
+// localizations returns a copy of the existing localizations with the messages of all locales supported by Discord added,
+// except the default locale, which is used for the name and description itself.
+func (b *bundleImpl) localizations(key string, existing map[discord.Locale]string) map[discord.Locale]string {
+	localizations := maps.Clone(existing)
+	for locale, catalog := range b.catalogs {
+		if locale == b.config.DefaultLocale || locale == discord.LocaleUnknown {
+			continue
+		}
+		if _, ok := discord.Locales[locale]; !ok {
+			continue
+		}
+		msg, ok := catalog[key]
+		if !ok || msg.plural != nil {
+			continue
+		}
+		if _, ok = localizations[locale]; ok {
+			continue
+		}
+		if localizations == nil {
+			localizations = make(map[discord.Locale]string)
+		}
+		localizations[locale] = msg.text
+	}
+	return localizations
+}
@@ -0,0 +1,12 @@
+// Package i18n provides message catalogs per discord.Locale with pluralization and placeholders.
+//
+// Catalogs are loaded from JSON files in an fs.FS with NewBundle. The Bundle can localize application commands by key
+// with Bundle.LocalizeCommands and creates Translator(s) with a fallback chain for a user, e.g. with the handler.Mux:
+//
+//	bundle, err := i18n.NewBundle(os.DirFS("locales"))
+//	r := handler.New()
+//	r.Bundle(bundle)
+//	r.SlashCommand("/ping", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+//		return e.CreateMessage(discord.MessageCreate{Content: e.Translator.T("pong", i18n.Args{"user": e.User().Username})})
+//	})
+package i18n
@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// PluralForm is a CLDR plural category used as key of plural messages.
+type PluralForm string
+
+const (
+	PluralZero  PluralForm = "zero"
+	PluralOne   PluralForm = "one"
+	PluralTwo   PluralForm = "two"
+	PluralFew   PluralForm = "few"
+	PluralMany  PluralForm = "many"
+	PluralOther PluralForm = "other"
+)
+
+var pluralForms = []PluralForm{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// PluralRule returns the PluralForm for the given count.
+type PluralRule func(n int) PluralForm
+
+// PluralRuleOther always returns PluralOther and is used by languages without plural forms like Japanese.
+func PluralRuleOther(int) PluralForm {
+	return PluralOther
+}
+
+// PluralRuleOneOther returns PluralOne for 1 and PluralOther otherwise, e.g. for English or German.
+func PluralRuleOneOther(n int) PluralForm {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleZeroOneOther returns PluralOne for 0 and 1 and PluralOther otherwise, e.g. for French or Portuguese (Brazil).
+func PluralRuleZeroOneOther(n int) PluralForm {
+	if n == 0 || n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleEastSlavic returns the plural forms of Russian, Ukrainian and Croatian.
+func PluralRuleEastSlavic(n int) PluralForm {
+	mod10, mod100 := abs(n)%10, abs(n)%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRulePolish returns the plural forms of Polish.
+func PluralRulePolish(n int) PluralForm {
+	mod10, mod100 := abs(n)%10, abs(n)%100
+	switch {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRuleCzech returns the plural forms of Czech.
+func PluralRuleCzech(n int) PluralForm {
+	switch {
+	case n == 1:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// PluralRuleRomanian returns the plural forms of Romanian.
+func PluralRuleRomanian(n int) PluralForm {
+	mod100 := abs(n) % 100
+	switch {
+	case n == 1:
+		return PluralOne
+	case n == 0 || (mod100 >= 2 && mod100 <= 19):
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// PluralRuleLithuanian returns the plural forms of Lithuanian.
+func PluralRuleLithuanian(n int) PluralForm {
+	mod10, mod100 := abs(n)%10, abs(n)%100
+	switch {
+	case mod100 >= 11 && mod100 <= 19:
+		return PluralOther
+	case mod10 == 1:
+		return PluralOne
+	case mod10 >= 2:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+var defaultPluralRules = map[string]PluralRule{
+	"ja": PluralRuleOther,
+	"ko": PluralRuleOther,
+	"zh": PluralRuleOther,
+	"id": PluralRuleOther,
+	"th": PluralRuleOther,
+	"vi": PluralRuleOther,
+	"fr": PluralRuleZeroOneOther,
+	"pt": PluralRuleZeroOneOther,
+	"hi": PluralRuleZeroOneOther,
+	"ru": PluralRuleEastSlavic,
+	"uk": PluralRuleEastSlavic,
+	"hr": PluralRuleEastSlavic,
+	"pl": PluralRulePolish,
+	"cs": PluralRuleCzech,
+	"ro": PluralRuleRomanian,
+	"lt": PluralRuleLithuanian,
+}
+
+// defaultPluralRule returns the PluralRule of the language of the locale and PluralRuleOneOther for all languages without special rules.
+func defaultPluralRule(locale discord.Locale) PluralRule {
+	language, _, _ := strings.Cut(string(locale), "-")
+	if rule, ok := defaultPluralRules[language]; ok {
+		return rule
+	}
+	return PluralRuleOneOther
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -48,7 +48,9 @@ type clientImpl struct {
 
 func (c *clientImpl) Close(ctx context.Context) {
 	c.config.RateLimiter.Close(ctx)
-	c.config.HTTPClient.CloseIdleConnections()
+	if !c.config.SharedHTTPClient {
+		c.config.HTTPClient.CloseIdleConnections()
+	}
 }
 
 func (c *clientImpl) HTTPClient() *http.Client {
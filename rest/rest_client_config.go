@@ -18,6 +18,7 @@ func defaultClientConfig() clientConfig {
 type clientConfig struct {
 	Logger                *slog.Logger
 	HTTPClient            *http.Client
+	SharedHTTPClient      bool
 	RateLimiter           RateLimiter
 	RateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                   string
@@ -48,6 +49,16 @@ func WithLogger(logger *slog.Logger) ClientConfigOpt {
 func WithHTTPClient(httpClient *http.Client) ClientConfigOpt {
 	return func(config *clientConfig) {
 		config.HTTPClient = httpClient
+		config.SharedHTTPClient = false
+	}
+}
+
+// WithSharedHTTPClient applies an http.Client which is shared with other rest clients.
+// Unlike with WithHTTPClient, closing the rest client does not close the idle connections of the shared http.Client.
+func WithSharedHTTPClient(httpClient *http.Client) ClientConfigOpt {
+	return func(config *clientConfig) {
+		config.HTTPClient = httpClient
+		config.SharedHTTPClient = true
 	}
 }
 